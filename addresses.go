@@ -0,0 +1,114 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type AddressDetails struct {
+	Country    string `json:"country"`
+	FirstLine  string `json:"firstLine"`
+	PostCode   string `json:"postCode"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	Occupation string `json:"occupation,omitempty"`
+}
+
+type Address struct {
+	ID      int            `json:"id"`
+	Profile int            `json:"profile"`
+	Details AddressDetails `json:"details"`
+}
+
+type addressRequest struct {
+	Profile int            `json:"profile"`
+	Details AddressDetails `json:"details"`
+}
+
+func (a *API) CreateAddress(profileID int, d AddressDetails, options ...ReqOption) (*Address, error) {
+	return a.CreateAddressContext(context.Background(), profileID, d, options...)
+}
+
+func (a *API) CreateAddressContext(ctx context.Context, profileID int, d AddressDetails, options ...ReqOption) (*Address, error) {
+	res := Address{}
+	if err := a.do(ctx, "v1/addresses", http.MethodPost, addressRequest{Profile: profileID, Details: d}, &res, options...); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (a *API) AddressByID(id int) (*Address, error) {
+	return a.AddressByIDContext(context.Background(), id)
+}
+
+func (a *API) AddressByIDContext(ctx context.Context, id int) (*Address, error) {
+	d := Address{}
+	url := fmt.Sprintf("v1/addresses/%d", id)
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) Addresses(profileID int) ([]Address, error) {
+	return a.AddressesContext(context.Background(), profileID)
+}
+
+func (a *API) AddressesContext(ctx context.Context, profileID int) ([]Address, error) {
+	res := []Address{}
+	url := fmt.Sprintf("v1/addresses?profile=%d", profileID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type addressRequirementsRequest struct {
+	Details AddressDetails `json:"details"`
+}
+
+func (a *API) AddressRequirements(d AddressDetails) (Requirements, error) {
+	return a.AddressRequirementsContext(context.Background(), d)
+}
+
+func (a *API) AddressRequirementsContext(ctx context.Context, d AddressDetails) (Requirements, error) {
+	if d.Country == "" {
+		return nil, fmt.Errorf("country is required")
+	}
+
+	res := Requirements{}
+	if err := a.do(ctx, "v1/address-requirements", http.MethodPost, addressRequirementsRequest{Details: d}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type AddressHolder interface {
+	PrimaryAddressID() int
+}
+
+func (p profile) PrimaryAddressID() int {
+	return p.Details.PrimaryAddress
+}
+
+func (p Person) PrimaryAddressID() int {
+	return p.Details.PrimaryAddress
+}
+
+func (b Business) PrimaryAddressID() int {
+	return b.Details.PrimaryAddress
+}
+
+func (a *API) PrimaryAddress(h AddressHolder) (*Address, error) {
+	return a.PrimaryAddressContext(context.Background(), h)
+}
+
+func (a *API) PrimaryAddressContext(ctx context.Context, h AddressHolder) (*Address, error) {
+	id := h.PrimaryAddressID()
+	if id == 0 {
+		return nil, fmt.Errorf("profile has no primary address")
+	}
+
+	return a.AddressByIDContext(ctx, id)
+}
@@ -0,0 +1,84 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAddresses(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/addresses/330":
+			w.Write([]byte(`{"id":330,"profile":220,"details":{"country":"US","state":"AZ","city":"Phoenix","postCode":"10025","firstLine":"50 Sunflower Ave"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/addresses":
+			if r.URL.Query().Get("profile") != "220" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"id":330,"profile":220},{"id":331,"profile":220}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/address-requirements":
+			req := addressRequirementsRequest{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Details.Country != "US" {
+				t.Errorf("unexpected request: %#v", req)
+			}
+			w.Write([]byte(`[{"type":"address","fields":[
+				{"name":"Country","group":[{"key":"country","type":"select","refreshRequirementsOnChange":true,"required":true,"valuesAllowed":[{"key":"US","name":"United States"}]}]},
+				{"name":"State","group":[{"key":"state","type":"select","required":true,"valuesAllowed":[{"key":"AZ","name":"Arizona"}]}]},
+				{"name":"Post code","group":[{"key":"postCode","type":"text","required":true,"minLength":5,"maxLength":10,"validationRegexp":"^\\d{5}(-\\d{4})?$"}]}
+			]}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Run("list", func(t *testing.T) {
+		addr, err := api.Addresses(220)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(addr) != 2 {
+			t.Errorf("expected 2 addresses, but got %d", len(addr))
+		}
+	})
+
+	t.Run("primary", func(t *testing.T) {
+		p := Person{ID: 220}
+		p.Details.PrimaryAddress = 330
+
+		addr, err := api.PrimaryAddress(p)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if addr.Details.City != "Phoenix" || addr.Details.State != "AZ" {
+			t.Errorf("unexpected address: %#v", addr)
+		}
+
+		if _, err := api.PrimaryAddress(Business{}); err == nil {
+			t.Errorf("expected an error without primary address")
+		}
+	})
+
+	t.Run("requirements", func(t *testing.T) {
+		req, err := api.AddressRequirements(AddressDetails{Country: "US"})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(req) != 1 || len(req[0].Fields) != 3 || req.ByType("address") == nil {
+			t.Fatalf("unexpected requirements: %#v", req)
+		}
+
+		pc := req[0].Fields[2].Group[0]
+		if pc.Key != "postCode" || pc.MinLength == nil || *pc.MinLength != 5 || pc.ValidationRegexp == "" {
+			t.Errorf("unexpected post code requirement: %#v", pc)
+		}
+
+		if !req[0].Fields[0].Group[0].RefreshRequirementsOnChange {
+			t.Errorf("expected country to refresh requirements on change")
+		}
+	})
+}
@@ -2,9 +2,14 @@ package transferwise
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +20,10 @@ const (
 	sandboxURL = "https://api.sandbox.transferwise.tech/"
 )
 
+var defaultClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
 func deepCopy(s interface{}, d interface{}) error {
 	b := new(bytes.Buffer)
 	if err := gob.NewEncoder(b).Encode(s); err != nil {
@@ -28,6 +37,17 @@ func deepCopy(s interface{}, d interface{}) error {
 	return nil
 }
 
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
 type TwDate struct {
 	time.Time
 }
@@ -42,7 +62,7 @@ func (d *TwDate) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func (d *TwDate) MarshalJSON() ([]byte, error) {
+func (d TwDate) MarshalJSON() ([]byte, error) {
 	// Using explicit functions to construct the date, because d.Format("2006-01-02") isn't working,
 	// it returns YYYY-MM-DD 00:00:00 +0000 UTC instead of just YYYY-MM-DD
 	t := d.Format("2006-01-02") //fmt.Sprintf("\"%d-%d-%d\"", d.Year(), d.Month(), d.Day())
@@ -53,6 +73,32 @@ func (d *TwDate) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", t)), nil
 }
 
+type TwTime struct {
+	time.Time
+}
+
+func (d *TwTime) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), "\"")
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	var err error
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02T15:04:05-0700"} {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			(*d) = TwTime{t}
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (d TwTime) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("\"%s\"", d.Format("2006-01-02 15:04:05"))), nil
+}
+
 type Language string
 
 var (
@@ -72,87 +118,198 @@ var (
 	Spanish                  = "es"
 )
 
-type APIError struct {
-	Errors []struct {
-		Code      string        `json:"code"`
-		Message   string        `json:"message"`
-		Path      string        `json:"path"`
-		Arguments []interface{} `json:"arguments"`
-	} `json:"errors"`
+type API struct {
+	url        string
+	token      string
+	lang       Language
+	client     *http.Client
+	signingKey *rsa.PrivateKey
+	retry      *RetryPolicy
+	limits     map[EndpointGroup]*bucket
+	store      IdempotencyStore
+	tokens     TokenSource
+	currencies *currencyRegistry
+}
+
+type ReqOption func(*http.Request) error
+
+func WithReqLanguage(l Language) ReqOption {
+	return func(r *http.Request) error {
+		r.Header.Set("Accept-Language", string(l))
+		return nil
+	}
 }
 
-func (a APIError) Error() string {
-	s := ""
-	l := len(a.Errors)
-	for _, err := range a.Errors {
-		s += fmt.Sprintf("%s: %s, path: %s, arguments: %v", err.Code, err.Message, err.Path, err.Arguments)
-		if l > 1 {
-			s += fmt.Sprintf("\n")
+// newRequest builds the request and applies the options once. The
+// Authorization header is set by send for every attempt.
+func (a *API) newRequest(ctx context.Context, url string, method string, body []byte, options ...ReqOption) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, a.url+url, bytes.NewReader(body))
+	if err != nil {
+		return nil, &RequestError{Op: "request creation", Err: err}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.lang != "" {
+		req.Header.Set("Accept-Language", string(a.lang))
+	}
+
+	for _, opt := range options {
+		if err := opt(req); err != nil {
+			return nil, &RequestError{Op: "option", Err: err}
 		}
 	}
 
-	return s
+	return req, nil
 }
 
-type API struct {
-	url   string
-	token string
-	lang  Language
+// attempt returns a copy of req with a fresh body and the current token.
+func (a *API) attempt(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, &RequestError{Op: "request creation", Err: err}
+		}
+		r.Body = b
+	}
+
+	token := a.token
+	if a.tokens != nil {
+		t, err := a.tokens.Token(req.Context())
+		if err != nil {
+			return nil, &RequestError{Op: "token", Err: err}
+		}
+		token = t.AccessToken
+	}
+
+	if token != "" {
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	return r, nil
 }
 
-type ReqOption func(*http.Request) error
+func (a *API) send(ctx context.Context, url string, r *http.Request) (*http.Response, int, error) {
+	for attempt := 1; ; attempt++ {
+		if err := a.throttle(ctx, url, r.Method); err != nil {
+			return nil, attempt, err
+		}
 
-func WithReqLanguage(l Language) ReqOption {
-	return func(r *http.Request) error {
-		r.Header.Set("Accept-Language", string(l))
+		req, err := a.attempt(r)
+		if err != nil {
+			return nil, attempt, err
+		}
+
+		res, err := a.client.Do(req)
+		if a.retry == nil || attempt >= a.retry.MaxAttempts || !a.retry.retryable(req, res, err) {
+			if err != nil {
+				return nil, attempt, &RequestError{Op: "client", Err: err}
+			}
+			return res, attempt, nil
+		}
+
+		wait := a.retry.backoff(attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, attempt, &RequestError{Op: "client", Err: ctx.Err()}
+		case <-t.C:
+		}
+	}
+}
+
+func decode(raw []byte, d interface{}) error {
+	if d == nil {
+		return nil
+	}
+
+	if r, ok := d.(*[]byte); ok {
+		*r = raw
 		return nil
 	}
+
+	if err := json.Unmarshal(raw, d); err != nil {
+		return &RequestError{Op: "json decoding", Err: err}
+	}
+
+	return nil
 }
 
-func (a *API) do(url string, method string, body interface{}, d interface{}, options ...ReqOption) error {
+func (a *API) do(ctx context.Context, url string, method string, body interface{}, d interface{}, options ...ReqOption) error {
 	b := new(bytes.Buffer)
 	if body != nil {
 		if err := json.NewEncoder(b).Encode(body); err != nil {
-			return fmt.Errorf("json encoding error: %v", err)
+			return &RequestError{Op: "json encoding", Err: err}
 		}
 	}
 
-	req, err := http.NewRequest(method, a.url+url, b)
+	req, err := a.newRequest(ctx, url, method, b.Bytes(), options...)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
-	req.Header.Set("Content-Type", "application/json")
-	if a.lang != "" {
-		req.Header.Set("Accept-Language", string(a.lang))
+	key := ""
+	if mutating(method) {
+		key = req.Header.Get(idempotenceHeader)
 	}
 
-	for _, opt := range options {
-		if err := opt(req); err != nil {
-			return fmt.Errorf("option error: %v", err)
+	if key != "" {
+		rec, err := a.checkIdempotency(key, url, method, b.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if rec != nil && rec.Completed {
+			return decode(rec.Response, d)
 		}
 	}
 
-	client := http.Client{}
-	res, err := client.Do(req)
+	res, attempts, err := a.send(ctx, url, req)
 	if err != nil {
-		return fmt.Errorf("client error: %v", err)
+		return a.retryError(attempts, err)
+	}
+
+	if ott := res.Header.Get(approvalHeader); res.StatusCode == http.StatusForbidden && ott != "" {
+		raw, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		challenge := newAPIError(res, raw)
+
+		n := 0
+		res, n, err = a.approve(ctx, url, req, ott)
+		attempts += n
+		if errors.Is(err, ErrSCARequired) {
+			return a.retryError(attempts, fmt.Errorf("%w: %w", err, challenge))
+		}
+
+		if err != nil {
+			return a.retryError(attempts, err)
+		}
 	}
 
 	defer res.Body.Close()
 
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return a.retryError(attempts, &RequestError{Op: "response reading", Err: err})
+	}
+
 	if res.StatusCode < http.StatusOK || res.StatusCode > http.StatusIMUsed {
-		err := APIError{}
-		json.NewDecoder(res.Body).Decode(&err)
-		return err
+		return a.retryError(attempts, newAPIError(res, raw))
 	}
 
-	if err := json.NewDecoder(res.Body).Decode(d); err != nil {
-		return fmt.Errorf("json decoding error: %v", err)
+	if key != "" {
+		if err := a.completeIdempotency(key, url, method, b.Bytes(), raw); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return decode(raw, d)
 }
 
 type APIOption func(*API) error
@@ -175,10 +332,36 @@ func WithLanguage(l Language) APIOption {
 	}
 }
 
+func WithHTTPClient(c *http.Client) APIOption {
+	return func(a *API) error {
+		if c == nil {
+			return fmt.Errorf("http client can't be nil")
+		}
+
+		a.client = c
+		return nil
+	}
+}
+
+func WithTransport(t http.RoundTripper) APIOption {
+	return func(a *API) error {
+		if t == nil {
+			return fmt.Errorf("transport can't be nil")
+		}
+
+		c := *a.client
+		c.Transport = t
+		a.client = &c
+		return nil
+	}
+}
+
 func New(token string, options ...APIOption) (*API, error) {
 	api := API{
-		url:   url,
-		token: token,
+		url:        url,
+		token:      token,
+		client:     defaultClient,
+		currencies: &currencyRegistry{},
 	}
 
 	for _, opt := range options {
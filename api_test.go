@@ -1,7 +1,11 @@
 package transferwise
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -55,6 +59,151 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func newTestAPI(t *testing.T, h http.Handler, options ...APIOption) *API {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	api, err := New("test-token", append([]APIOption{WithURL(srv.URL + "/")}, options...)...)
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	return api
+}
+
+func TestContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/profiles":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Errorf("expected bearer token, but got %v", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`[{"id":1,"type":"personal"}]`))
+		default:
+			select {
+			case <-block:
+			case <-r.Context().Done():
+			}
+		}
+	}))
+
+	t.Run("success", func(t *testing.T) {
+		p, err := api.ProfilesContext(context.Background())
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(p) != 1 || p[0].ID != 1 {
+			t.Errorf("expected one profile with ID 1, but got %v", p)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := api.QuoteByIDContext(ctx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, but got %v", err)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, err := api.GetProfileContext(ctx, 1)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context canceled, but got %v", err)
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestHTTPClient(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		a1, _ := New("a")
+		a2, _ := New("b")
+		if a1.client != a2.client || a1.client != defaultClient {
+			t.Errorf("expected the default client to be shared")
+		}
+
+		if defaultClient.Timeout == 0 {
+			t.Errorf("expected the default client to have a timeout")
+		}
+	})
+
+	t.Run("withHTTPClient", func(t *testing.T) {
+		calls := 0
+		c := &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				return http.DefaultTransport.RoundTrip(r)
+			}),
+		}
+
+		api := newTestAPI(t, h, WithHTTPClient(c))
+		if api.client != c {
+			t.Fatalf("expected the supplied client to be used")
+		}
+
+		if _, err := api.Profiles(); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("expected 1 call through the client, but got %d", calls)
+		}
+	})
+
+	t.Run("withTransport", func(t *testing.T) {
+		calls := 0
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return http.DefaultTransport.RoundTrip(r)
+		})
+
+		api := newTestAPI(t, h, WithTransport(rt))
+		if defaultClient.Transport != nil {
+			t.Fatalf("expected the default client to be left untouched")
+		}
+
+		if api.client.Timeout != defaultClient.Timeout {
+			t.Errorf("expected timeout %v, but got %v", defaultClient.Timeout, api.client.Timeout)
+		}
+
+		if _, err := api.Profiles(); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("expected 1 call through the transport, but got %d", calls)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if _, err := New("a", WithHTTPClient(nil)); err == nil {
+			t.Errorf("expected an error for a nil client")
+		}
+
+		if _, err := New("a", WithTransport(nil)); err == nil {
+			t.Errorf("expected an error for a nil transport")
+		}
+	})
+}
+
 func TestTwDate(t *testing.T) {
 	type profile struct {
 		Name string `json:"name"`
@@ -129,8 +278,8 @@ func TestTwDate(t *testing.T) {
 // 		t.Fatalf("expected to pass, but got: %v", err)
 // 	}
 
-// 	if r.TargetAmount <= None {
-// 		t.Errorf("expected a targetAmount, but got %.2f", r.TargetAmount)
+// 	if r.TargetAmount.Sign() <= 0 {
+// 		t.Errorf("expected a targetAmount, but got %v", r.TargetAmount)
 // 	}
 
 // 	log.Printf("got quote: %v", r)
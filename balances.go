@@ -0,0 +1,181 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Balance struct {
+	ID             int    `json:"id"`
+	BalanceType    string `json:"balanceType"`
+	Currency       string `json:"currency"`
+	Amount         Amount `json:"amount"`
+	ReservedAmount Amount `json:"reservedAmount"`
+	BankDetails    *struct {
+		ID                int              `json:"id"`
+		Currency          string           `json:"currency"`
+		BankCode          string           `json:"bankCode"`
+		AccountNumber     string           `json:"accountNumber"`
+		Swift             string           `json:"swift"`
+		IBAN              string           `json:"iban"`
+		BankName          string           `json:"bankName"`
+		AccountHolderName string           `json:"accountHolderName"`
+		BankAddress       RecipientAddress `json:"bankAddress"`
+	} `json:"bankDetails"`
+}
+
+func (b Balance) Available() Amount {
+	return b.Amount
+}
+
+func (b Balance) Reserved() Amount {
+	return b.ReservedAmount
+}
+
+type BalanceAccount struct {
+	ID               int       `json:"id"`
+	ProfileID        int       `json:"profileId"`
+	RecipientID      int       `json:"recipientId"`
+	CreationTime     TwTime    `json:"creationTime"`
+	ModificationTime TwTime    `json:"modificationTime"`
+	Active           bool      `json:"active"`
+	Eligible         bool      `json:"eligible"`
+	Balances         []Balance `json:"balances"`
+}
+
+func (b BalanceAccount) Balance(currency string) *Balance {
+	for i := range b.Balances {
+		if b.Balances[i].Currency == currency {
+			return &b.Balances[i]
+		}
+	}
+
+	return nil
+}
+
+func (a *API) BalanceAccounts(profileID int) ([]BalanceAccount, error) {
+	return a.BalanceAccountsContext(context.Background(), profileID)
+}
+
+func (a *API) BalanceAccountsContext(ctx context.Context, profileID int) ([]BalanceAccount, error) {
+	res := []BalanceAccount{}
+	url := fmt.Sprintf("v1/borderless-accounts?profileId=%d", profileID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type openBalanceRequest struct {
+	Currency string `json:"currency"`
+}
+
+func (a *API) OpenBalance(accountID int, currency string, options ...ReqOption) (*Balance, error) {
+	return a.OpenBalanceContext(context.Background(), accountID, currency, options...)
+}
+
+func (a *API) OpenBalanceContext(ctx context.Context, accountID int, currency string, options ...ReqOption) (*Balance, error) {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	d := Balance{}
+	url := fmt.Sprintf("v1/borderless-accounts/%d/balances", accountID)
+	if err := a.do(ctx, url, http.MethodPost, openBalanceRequest{Currency: string(c)}, &d, options...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) CloseBalance(accountID int, currency string, options ...ReqOption) error {
+	return a.CloseBalanceContext(context.Background(), accountID, currency, options...)
+}
+
+func (a *API) CloseBalanceContext(ctx context.Context, accountID int, currency string, options ...ReqOption) error {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("v1/borderless-accounts/%d/balances/%s", accountID, c)
+	return a.do(ctx, url, http.MethodDelete, nil, nil, options...)
+}
+
+type BalanceMovement struct {
+	ID       int     `json:"id"`
+	Value    Decimal `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+type ConversionStep struct {
+	ID                   int               `json:"id"`
+	Type                 string            `json:"type"`
+	CreationTime         TwTime            `json:"creationTime"`
+	BalancesAfter        []BalanceMovement `json:"balancesAfter"`
+	ChannelName          string            `json:"channelName"`
+	ChannelReferenceID   string            `json:"channelReferenceId"`
+	TracingReferenceCode string            `json:"tracingReferenceCode"`
+	SourceAmount         Amount            `json:"sourceAmount"`
+	TargetAmount         Amount            `json:"targetAmount"`
+	Fee                  Amount            `json:"fee"`
+	Rate                 Decimal           `json:"rate"`
+}
+
+type Conversion struct {
+	ID            int               `json:"id"`
+	Type          string            `json:"type"`
+	State         string            `json:"state"`
+	BalancesAfter []BalanceMovement `json:"balancesAfter"`
+	CreationTime  TwTime            `json:"creationTime"`
+	Steps         []ConversionStep  `json:"steps"`
+	SourceAmount  Amount            `json:"sourceAmount"`
+	TargetAmount  Amount            `json:"targetAmount"`
+	Rate          Decimal           `json:"rate"`
+	FeeAmounts    []Amount          `json:"feeAmounts"`
+}
+
+type conversionRequest struct {
+	QuoteID int `json:"quoteId"`
+}
+
+func (b BalanceAccount) validateConversion(q QuoteResponse) error {
+	if q.Type != BalanceConversion {
+		return fmt.Errorf("expected quote of type %v, but got %v", BalanceConversion, q.Type)
+	}
+
+	if q.Profile != b.ProfileID {
+		return fmt.Errorf("quote belongs to profile %d, but account belongs to profile %d", q.Profile, b.ProfileID)
+	}
+
+	if !q.Expiration.IsZero() && time.Now().After(q.Expiration) {
+		return fmt.Errorf("quote %d expired at %v", q.ID, q.Expiration)
+	}
+
+	if b.Balance(q.Source) == nil {
+		return fmt.Errorf("account %d has no %s balance", b.ID, q.Source)
+	}
+
+	return nil
+}
+
+func (a *API) ConvertBalance(acc BalanceAccount, q QuoteResponse, options ...ReqOption) (*Conversion, error) {
+	return a.ConvertBalanceContext(context.Background(), acc, q, options...)
+}
+
+func (a *API) ConvertBalanceContext(ctx context.Context, acc BalanceAccount, q QuoteResponse, options ...ReqOption) (*Conversion, error) {
+	if err := acc.validateConversion(q); err != nil {
+		return nil, err
+	}
+
+	d := Conversion{}
+	url := fmt.Sprintf("v1/borderless-accounts/%d/conversions", acc.ID)
+	key := WithIdempotencyKey(derivedKey("conversion", strconv.Itoa(acc.ID), strconv.Itoa(q.ID)))
+	if err := a.do(ctx, url, http.MethodPost, conversionRequest{QuoteID: q.ID}, &d, append([]ReqOption{key}, options...)...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
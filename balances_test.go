@@ -0,0 +1,145 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBalances(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/borderless-accounts":
+			if r.URL.Query().Get("profileId") != "1" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+
+			w.Write([]byte(`[{"id":64,"profileId":1,"active":true,"creationTime":"2018-03-14T12:31:15.678Z","balances":[
+				{"id":103,"balanceType":"AVAILABLE","currency":"EUR","amount":{"value":9998.5,"currency":"EUR"},"reservedAmount":{"value":1.5,"currency":"EUR"}},
+				{"id":104,"balanceType":"AVAILABLE","currency":"GBP","amount":{"value":0,"currency":"GBP"},"reservedAmount":{"value":0,"currency":"GBP"}}
+			]}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/borderless-accounts/64/balances":
+			req := openBalanceRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"id":105,"balanceType":"AVAILABLE","currency":"` + req.Currency + `"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/borderless-accounts/64/balances/USD":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Run("list", func(t *testing.T) {
+		acc, err := api.BalanceAccounts(1)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(acc) != 1 || len(acc[0].Balances) != 2 {
+			t.Fatalf("unexpected accounts: %#v", acc)
+		}
+
+		b := acc[0].Balance("EUR")
+		if b == nil {
+			t.Fatalf("expected an EUR balance")
+		}
+
+		if !b.Available().Value.Equal(MustParseDecimal("9998.5")) || !b.Reserved().Value.Equal(MustParseDecimal("1.5")) {
+			t.Errorf("unexpected amounts: %v available, %v reserved", b.Available(), b.Reserved())
+		}
+
+		if acc[0].Balance("USD") != nil {
+			t.Errorf("expected no USD balance")
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		b, err := api.OpenBalance(64, " usd")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if b.Currency != "USD" {
+			t.Errorf("expected currency USD, but got %v", b.Currency)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		if err := api.CloseBalance(64, "usd"); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+	})
+}
+
+func TestConvertBalance(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/borderless-accounts/64/conversions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if k := r.Header.Get(idempotenceHeader); k != derivedKey("conversion", "64", "42") {
+			t.Errorf("expected an idempotence uuid derived from the quote, but got %q", k)
+		}
+
+		req := conversionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("expected to pass, but got %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.QuoteID != 42 {
+			t.Errorf("expected quote 42, but got %d", req.QuoteID)
+		}
+
+		w.Write([]byte(`{"id":30000,"type":"CONVERSION","state":"COMPLETED","balancesAfter":[{"id":103,"value":9900,"currency":"EUR"},{"id":104,"value":87.7,"currency":"GBP"}],"sourceAmount":{"value":100,"currency":"EUR"},"targetAmount":{"value":87.7,"currency":"GBP"},"rate":0.88,"feeAmounts":[{"value":0.3,"currency":"EUR"}]}`))
+	}))
+
+	acc := BalanceAccount{
+		ID:        64,
+		ProfileID: 1,
+		Balances:  []Balance{{Currency: "EUR"}, {Currency: "GBP"}},
+	}
+
+	q := QuoteResponse{
+		ID:         42,
+		Profile:    1,
+		Source:     "EUR",
+		Target:     "GBP",
+		Type:       BalanceConversion,
+		Expiration: time.Now().Add(time.Hour),
+	}
+
+	t.Run("convert", func(t *testing.T) {
+		c, err := api.ConvertBalance(acc, q)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if c.State != "COMPLETED" || len(c.BalancesAfter) != 2 || c.Rate.String() != "0.88" {
+			t.Errorf("unexpected conversion: %#v", c)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, f := range map[string]func(q *QuoteResponse){
+			"type":    func(q *QuoteResponse) { q.Type = BalancePayout },
+			"profile": func(q *QuoteResponse) { q.Profile = 2 },
+			"expired": func(q *QuoteResponse) { q.Expiration = time.Now().Add(-time.Minute) },
+			"source":  func(q *QuoteResponse) { q.Source = "USD" },
+		} {
+			iq := q
+			f(&iq)
+			if _, err := api.ConvertBalance(acc, iq); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
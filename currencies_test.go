@@ -0,0 +1,115 @@
+package transferwise
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCurrency(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		c, err := ParseCurrency(" eur ")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if c != "EUR" {
+			t.Errorf("expected EUR, but got %v", c)
+		}
+
+		if _, err := ParseCurrency("EURO"); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+	})
+
+	t.Run("info", func(t *testing.T) {
+		tests := map[Currency]int32{"JPY": 0, "BHD": 3, "EUR": 2, "XXX": 2}
+		for c, u := range tests {
+			if m := c.MinorUnits(); m != u {
+				t.Errorf("expected %d minor units for %v, but got %d", u, c, m)
+			}
+		}
+
+		i, ok := Currency("GBP").Info()
+		if !ok || i.Numeric != 826 || i.Name != "Pound Sterling" {
+			t.Errorf("unexpected currency info: %#v", i)
+		}
+	})
+
+	t.Run("builders", func(t *testing.T) {
+		p := profile{ID: 1}
+		if _, err := p.QuoteRequest("EURO", "GBP", None, 100, BalancePayout); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+
+		q, err := p.QuoteRequest("eur", "gbp", None, 100, BalancePayout)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if q.Source != "EUR" || q.Target != "GBP" {
+			t.Errorf("expected normalized currencies, but got %v and %v", q.Source, q.Target)
+		}
+
+		if _, err := p.RecipientRequest("GB", "Jane Doe", EmailDetails{Email: "jane@example.com"}); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+	})
+}
+
+func TestSupportedCurrencies(t *testing.T) {
+	calls := 0
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/v1/currencies":
+			w.Write([]byte(`[{"code":"EUR","symbol":"€","name":"Euro","countryKeywords":["de"],"supportsDecimals":true},{"code":"GBP","symbol":"£","name":"British pound","supportsDecimals":true}]`))
+		case "/v1/currency-pairs":
+			w.Write([]byte(`{"sourceCurrencies":[{"currencyCode":"EUR","maxInvoiceAmount":1000000,"targetCurrencies":[{"currencyCode":"GBP","minInvoiceAmount":1,"fixedTargetPaymentAllowed":true}]}],"total":1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	s, err := api.LoadCurrencies()
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	if s.Currencies["EUR"].Symbol != "€" || !s.IsSource("EUR") || s.IsSource("GBP") || !s.IsTarget("GBP") {
+		t.Errorf("unexpected supported currencies: %#v", s)
+	}
+
+	if s.Pairs["EUR"]["GBP"].MinInvoiceAmount.String() != "1" {
+		t.Errorf("unexpected currency pair: %#v", s.Pairs["EUR"]["GBP"])
+	}
+
+	calls = 0
+	p := profile{ID: 1}
+	q, err := p.QuoteRequest("GBP", "EUR", None, 100, BalancePayout)
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	if _, err := api.Quote(q); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error, but got %v", err)
+	}
+
+	if _, err := api.TemoraryQuote("GBP", "EUR", None, 100); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error, but got %v", err)
+	}
+
+	r, err := p.RecipientRequest("EUR", "Jane Doe", EmailDetails{Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	if _, err := api.CreateRecipient(r); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error, but got %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no requests for unsupported currencies, but got %d", calls)
+	}
+}
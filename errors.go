@@ -0,0 +1,134 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrSCARequired  = errors.New("strong customer authentication required")
+	ErrValidation   = errors.New("validation failed")
+)
+
+type RequestError struct {
+	Op  string
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s error: %v", e.Op, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+type FieldError struct {
+	Code      string        `json:"code"`
+	Message   string        `json:"message"`
+	Path      string        `json:"path"`
+	Arguments []interface{} `json:"arguments"`
+}
+
+type APIError struct {
+	StatusCode int    `json:"-"`
+	RequestID  string `json:"-"`
+	Body       []byte `json:"-"`
+
+	Errors []FieldError `json:"errors"`
+	// OAuth endpoints report failures as error and error_description.
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	Message     string `json:"message"`
+
+	approval string
+}
+
+func newAPIError(res *http.Response, body []byte) APIError {
+	e := APIError{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &e); err != nil {
+			e.Message = strings.TrimSpace(string(body))
+		}
+	}
+
+	e.StatusCode = res.StatusCode
+	e.Body = body
+	e.approval = res.Header.Get(approvalHeader)
+	for _, h := range []string{"X-Request-Id", "X-Trace-Id", "X-Correlation-Id"} {
+		if id := res.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+
+	return e
+}
+
+func (a APIError) FieldErrors() map[string][]FieldError {
+	m := map[string][]FieldError{}
+	for _, err := range a.Errors {
+		m[err.Path] = append(m[err.Path], err)
+	}
+
+	return m
+}
+
+func (a APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return a.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return a.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return a.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return a.StatusCode == http.StatusTooManyRequests
+	case ErrSCARequired:
+		return a.StatusCode == http.StatusForbidden && a.approval != ""
+	case ErrValidation:
+		return len(a.Errors) > 0 && (a.StatusCode == http.StatusBadRequest || a.StatusCode == http.StatusUnprocessableEntity)
+	}
+
+	return false
+}
+
+func (a APIError) Error() string {
+	s := ""
+	if a.StatusCode != 0 {
+		s = fmt.Sprintf("status %d", a.StatusCode)
+		if a.RequestID != "" {
+			s += fmt.Sprintf(" (request %s)", a.RequestID)
+		}
+		s += ": "
+	}
+
+	switch {
+	case len(a.Errors) > 0:
+		l := len(a.Errors)
+		for _, err := range a.Errors {
+			s += fmt.Sprintf("%s: %s, path: %s, arguments: %v", err.Code, err.Message, err.Path, err.Arguments)
+			if l > 1 {
+				s += fmt.Sprintf("\n")
+			}
+		}
+	case a.Code != "":
+		s += a.Code
+		if a.Description != "" {
+			s += ": " + a.Description
+		}
+	case a.Message != "":
+		s += a.Message
+	default:
+		s += http.StatusText(a.StatusCode)
+	}
+
+	return s
+}
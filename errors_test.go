@@ -0,0 +1,129 @@
+package transferwise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace-Id", "trace-1")
+		switch r.URL.Path {
+		case "/v1/quotes":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors":[
+				{"code":"NOT_VALID","message":"Source amount is too low","path":"sourceAmount","arguments":[1]},
+				{"code":"NOT_VALID","message":"Please specify the currency","path":"source"},
+				{"code":"ALSO_NOT_VALID","message":"Minimum is 2","path":"sourceAmount"}
+			]}`))
+		case "/v1/quotes/1":
+			w.WriteHeader(http.StatusNotFound)
+		case "/v1/quotes/2":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_token","error_description":"Access token expired"}`))
+		case "/v1/quotes/3":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+		case "/v1/quotes/4":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/v1/quotes/5":
+			w.Write([]byte("not json"))
+		}
+	}))
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := api.Quote(quoteRequest{})
+
+		var ae APIError
+		if !errors.As(err, &ae) {
+			t.Fatalf("expected an APIError, but got %v", err)
+		}
+
+		if ae.StatusCode != http.StatusUnprocessableEntity || ae.RequestID != "trace-1" {
+			t.Errorf("unexpected status %d or request ID %q", ae.StatusCode, ae.RequestID)
+		}
+
+		f := ae.FieldErrors()
+		if len(f["sourceAmount"]) != 2 || len(f["source"]) != 1 {
+			t.Errorf("unexpected field errors: %v", f)
+		}
+
+		if !errors.Is(err, ErrValidation) || errors.Is(err, ErrNotFound) {
+			t.Errorf("expected a validation error only")
+		}
+	})
+
+	t.Run("notFound", func(t *testing.T) {
+		_, err := api.QuoteByID(1)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected not found, but got %v", err)
+		}
+	})
+
+	t.Run("oauth", func(t *testing.T) {
+		_, err := api.QuoteByID(2)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected unauthorized, but got %v", err)
+		}
+
+		var ae APIError
+		errors.As(err, &ae)
+		if ae.Code != "invalid_token" || !strings.Contains(err.Error(), "Access token expired") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("nonJSON", func(t *testing.T) {
+		_, err := api.QuoteByID(3)
+
+		var ae APIError
+		if !errors.As(err, &ae) {
+			t.Fatalf("expected an APIError, but got %v", err)
+		}
+
+		if ae.Message != "<html>bad gateway</html>" || string(ae.Body) != "<html>bad gateway</html>" {
+			t.Errorf("expected the raw body to be kept, but got %#v", ae)
+		}
+	})
+
+	t.Run("rateLimited", func(t *testing.T) {
+		if _, err := api.QuoteByID(4); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("expected rate limited, but got %v", err)
+		}
+	})
+
+	t.Run("decoding", func(t *testing.T) {
+		_, err := api.QuoteByID(5)
+
+		var re *RequestError
+		if !errors.As(err, &re) || re.Op != "json decoding" {
+			t.Errorf("expected a decoding RequestError, but got %v", err)
+		}
+	})
+
+	t.Run("network", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := api.QuoteByIDContext(ctx, 1)
+
+		var re *RequestError
+		if !errors.As(err, &re) || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected a client RequestError wrapping the cause, but got %v", err)
+		}
+	})
+
+	t.Run("sca", func(t *testing.T) {
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-2FA-Approval", "ott")
+			w.WriteHeader(http.StatusForbidden)
+		}))
+
+		if _, err := api.FundTransfer(1, 2); !errors.Is(err, ErrSCARequired) {
+			t.Errorf("expected SCA required, but got %v", err)
+		}
+	})
+}
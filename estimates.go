@@ -0,0 +1,297 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Estimate is an unauthenticated price estimate. Unlike a profile quote it
+// can't be used to create a transfer.
+type Estimate struct {
+	SourceCurrency     string          `json:"sourceCurrency"`
+	TargetCurrency     string          `json:"targetCurrency"`
+	SourceAmount       Decimal         `json:"sourceAmount"`
+	TargetAmount       Decimal         `json:"targetAmount"`
+	PayOut             PaymentMethod   `json:"payOut"`
+	Rate               Decimal         `json:"rate"`
+	RateType           string          `json:"rateType"`
+	Created            time.Time       `json:"createdTime"`
+	RateExpiration     time.Time       `json:"rateExpirationTime"`
+	ProvidedAmountType string          `json:"providedAmountType"`
+	PaymentOptions     []PaymentOption `json:"paymentOptions"`
+	Notices            []QuoteNotice   `json:"notices"`
+}
+
+func (e Estimate) Options() []PaymentOption {
+	return enabledOptions(e.PaymentOptions, e.PayOut)
+}
+
+func (e Estimate) Cheapest() (*PaymentOption, error) {
+	return pickOption(e.Options(), cheaper)
+}
+
+func (e Estimate) Fastest() (*PaymentOption, error) {
+	return pickOption(e.Options(), faster)
+}
+
+func (e Estimate) Option(payIn PaymentMethod) (*PaymentOption, error) {
+	return findOption(e.Options(), payIn)
+}
+
+type estimateRequest struct {
+	SourceCurrency string   `json:"sourceCurrency"`
+	TargetCurrency string   `json:"targetCurrency"`
+	SourceAmount   *Decimal `json:"sourceAmount,omitempty"`
+	TargetAmount   *Decimal `json:"targetAmount,omitempty"`
+}
+
+func EstimateRequest(source, target string, targetAmount, sourceAmount Decimal) (estimateRequest, error) {
+	s, t, ta, sa, err := quoteAmounts(source, target, targetAmount, sourceAmount)
+	if err != nil {
+		return estimateRequest{}, err
+	}
+
+	return estimateRequest{
+		SourceCurrency: string(s),
+		TargetCurrency: string(t),
+		SourceAmount:   sa,
+		TargetAmount:   ta,
+	}, nil
+}
+
+// anonymous returns a copy of the API that sends requests without credentials
+// and doesn't record them in the idempotency store.
+func (a *API) anonymous() *API {
+	c := *a
+	c.token = ""
+	c.tokens = nil
+	c.store = nil
+	return &c
+}
+
+func (a *API) Estimate(r estimateRequest) (*Estimate, error) {
+	return a.EstimateContext(context.Background(), r)
+}
+
+func (a *API) EstimateContext(ctx context.Context, r estimateRequest) (*Estimate, error) {
+	if r.SourceCurrency == "" || r.TargetCurrency == "" {
+		return nil, fmt.Errorf("%w: source and target currency are required", ErrValidation)
+	}
+
+	if err := a.checkPair(r.SourceCurrency, r.TargetCurrency); err != nil {
+		return nil, err
+	}
+
+	d := Estimate{}
+	if err := a.anonymous().do(ctx, "v3/quotes", http.MethodPost, r, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (e Estimate) quoteResponse() *QuoteResponse {
+	q := QuoteResponse{
+		Source:         e.SourceCurrency,
+		Target:         e.TargetCurrency,
+		RateType:       e.RateType,
+		SourceAmount:   e.SourceAmount,
+		TargetAmount:   e.TargetAmount,
+		Rate:           e.Rate,
+		Created:        e.Created,
+		Expiration:     e.RateExpiration,
+		OfSourceAmount: e.ProvidedAmountType == "SOURCE",
+	}
+
+	if o, err := e.Cheapest(); err == nil {
+		q.Fee = o.Fee.Total
+		q.DeliveryEstimate = o.EstimatedDelivery
+		q.SourceAmount = o.SourceAmount
+		q.TargetAmount = o.TargetAmount
+	}
+
+	return &q
+}
+
+// TemoraryQuote returns an unauthenticated quote. The misspelled TemoraryQuote
+// functions are kept for compatibility only; Estimate replaces all of them and
+// no correctly spelled variant will be added.
+//
+// Deprecated: use Estimate, which also returns the available payment options.
+func (a *API) TemoraryQuote(source, target string, targetAmount, sourceAmount float64) (*QuoteResponse, error) {
+	return a.TemoraryQuoteContext(context.Background(), source, target, targetAmount, sourceAmount)
+}
+
+// Deprecated: use EstimateContext.
+func (a *API) TemoraryQuoteContext(ctx context.Context, source, target string, targetAmount, sourceAmount float64) (*QuoteResponse, error) {
+	return a.TemoraryQuoteDecimalContext(ctx, source, target, DecimalFromFloat(targetAmount), DecimalFromFloat(sourceAmount))
+}
+
+// Deprecated: use Estimate.
+func (a *API) TemoraryQuoteDecimal(source, target string, targetAmount, sourceAmount Decimal) (*QuoteResponse, error) {
+	return a.TemoraryQuoteDecimalContext(context.Background(), source, target, targetAmount, sourceAmount)
+}
+
+// Deprecated: use EstimateContext.
+func (a *API) TemoraryQuoteDecimalContext(ctx context.Context, source, target string, targetAmount, sourceAmount Decimal) (*QuoteResponse, error) {
+	r, err := EstimateRequest(source, target, targetAmount, sourceAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := a.EstimateContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.quoteResponse(), nil
+}
+
+type estimateKey struct {
+	source      string
+	target      string
+	fixedTarget bool
+	bucket      string
+}
+
+type estimateEntry struct {
+	e       Estimate
+	amount  Decimal
+	expires time.Time
+}
+
+// EstimateCache caches estimates by currency pair and amount bucket. A cached
+// estimate is repriced for the requested amount with the rate and fees of the
+// first amount requested in its bucket, so fees that depend on the amount are
+// approximate.
+type EstimateCache struct {
+	api        *API
+	ttl        time.Duration
+	bucketSize Decimal
+
+	mu      sync.Mutex
+	entries map[estimateKey]estimateEntry
+}
+
+func NewEstimateCache(a *API, ttl time.Duration, bucketSize Decimal) (*EstimateCache, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("cache ttl must be positive")
+	}
+
+	if bucketSize.Sign() <= 0 {
+		return nil, fmt.Errorf("bucket size must be positive")
+	}
+
+	return &EstimateCache{
+		api:        a,
+		ttl:        ttl,
+		bucketSize: bucketSize,
+		entries:    map[estimateKey]estimateEntry{},
+	}, nil
+}
+
+func requestedAmount(r estimateRequest) (Decimal, bool) {
+	if r.TargetAmount != nil {
+		return *r.TargetAmount, true
+	}
+
+	if r.SourceAmount != nil {
+		return *r.SourceAmount, false
+	}
+
+	return Decimal{}, false
+}
+
+func (c *EstimateCache) key(r estimateRequest) estimateKey {
+	amount, fixedTarget := requestedAmount(r)
+	k := estimateKey{source: r.SourceCurrency, target: r.TargetCurrency, fixedTarget: fixedTarget}
+
+	a, s := align(amount, c.bucketSize)
+	k.bucket = new(big.Int).Quo(a.int(), s.int()).String()
+	return k
+}
+
+// reprice returns a copy of e for amount, keeping the rate and the fee of each
+// payment option.
+func (e Estimate) reprice(amount Decimal, fixedTarget bool) Estimate {
+	if e.Rate.IsZero() {
+		return e
+	}
+
+	source, target := MinorUnits(e.SourceCurrency), MinorUnits(e.TargetCurrency)
+
+	price := func(fee Decimal) (Decimal, Decimal) {
+		if fixedTarget {
+			return amount.quo(e.Rate, source).Add(fee), amount
+		}
+		return amount, amount.Sub(fee).Mul(e.Rate).Round(target)
+	}
+
+	fee := Decimal{}
+	if o, err := e.Cheapest(); err == nil {
+		fee = o.Fee.Total
+	}
+	e.SourceAmount, e.TargetAmount = price(fee)
+
+	options := make([]PaymentOption, len(e.PaymentOptions))
+	for i, o := range e.PaymentOptions {
+		o.SourceAmount, o.TargetAmount = price(o.Fee.Total)
+		options[i] = o
+	}
+	e.PaymentOptions = options
+
+	return e
+}
+
+func (c *EstimateCache) Estimate(r estimateRequest) (*Estimate, error) {
+	return c.EstimateContext(context.Background(), r)
+}
+
+func (c *EstimateCache) EstimateContext(ctx context.Context, r estimateRequest) (*Estimate, error) {
+	k := c.key(r)
+	amount, fixedTarget := requestedAmount(r)
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[k]
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		e := entry.e
+		if !amount.Equal(entry.amount) {
+			e = e.reprice(amount, fixedTarget)
+		}
+		return &e, nil
+	}
+
+	e, err := c.api.EstimateContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := now.Add(c.ttl)
+	if !e.RateExpiration.IsZero() && e.RateExpiration.Before(expires) {
+		expires = e.RateExpiration
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[k] = estimateEntry{e: *e, amount: amount, expires: expires}
+
+	return e, nil
+}
+
+func (c *EstimateCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[estimateKey]estimateEntry{}
+}
@@ -0,0 +1,136 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEstimates(t *testing.T) {
+	calls := 0
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/quotes" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if h := r.Header.Get("Authorization"); h != "" {
+			t.Errorf("expected no authorization header, but got %v", h)
+		}
+
+		req := estimateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("expected to pass, but got %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.SourceCurrency != "EUR" || req.TargetCurrency != "GBP" || req.SourceAmount == nil || req.TargetAmount != nil {
+			t.Errorf("unexpected request: %#v", req)
+		}
+
+		expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		w.Write([]byte(`{"sourceCurrency":"EUR","targetCurrency":"GBP","sourceAmount":` + req.SourceAmount.String() + `,"payOut":"BANK_TRANSFER","rate":0.8574,"rateType":"FIXED","rateExpirationTime":"` + expires + `","providedAmountType":"SOURCE",
+		"paymentOptions":[
+			{"estimatedDelivery":"2019-04-08T12:30:00Z","fee":{"total":0.62},"sourceAmount":100,"targetAmount":85.21,"sourceCurrency":"EUR","targetCurrency":"GBP","payIn":"BANK_TRANSFER","payOut":"BANK_TRANSFER"},
+			{"estimatedDelivery":"2019-04-05T14:30:00Z","fee":{"total":1.41},"sourceAmount":100,"targetAmount":84.53,"sourceCurrency":"EUR","targetCurrency":"GBP","payIn":"DEBIT","payOut":"BANK_TRANSFER"}
+		]}`))
+	}), WithIdempotencyStore(NewMemoryIdempotencyStore()))
+
+	t.Run("estimate", func(t *testing.T) {
+		r, err := EstimateRequest("eur", "gbp", Decimal{}, NewDecimal(100, 0))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		e, err := api.Estimate(r)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if e.Rate.String() != "0.8574" || len(e.PaymentOptions) != 2 {
+			t.Errorf("unexpected estimate: %#v", e)
+		}
+
+		if o, err := e.Cheapest(); err != nil || o.PayIn != BankTransfer {
+			t.Errorf("expected the bank transfer option to be cheapest, but got %v (%v)", o, err)
+		}
+
+		if o, err := e.Fastest(); err != nil || o.PayIn != DebitCard {
+			t.Errorf("expected the debit option to be fastest, but got %v (%v)", o, err)
+		}
+
+		if _, err := EstimateRequest("EUR", "GBPP", Decimal{}, NewDecimal(100, 0)); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+
+		if _, err := EstimateRequest("EUR", "GBP", NewDecimal(100, 0), NewDecimal(100, 0)); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error for two amounts, but got %v", err)
+		}
+
+		if _, err := api.Estimate(estimateRequest{SourceCurrency: "EUR"}); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error without a target currency, but got %v", err)
+		}
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		q, err := api.TemoraryQuote("EUR", "GBP", None, 100)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if q.Rate.String() != "0.8574" || q.Fee.String() != "0.62" || q.TargetAmount.String() != "85.21" || !q.OfSourceAmount {
+			t.Errorf("unexpected quote: %#v", q)
+		}
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		c, err := NewEstimateCache(api, time.Minute, NewDecimal(10, 0))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		calls = 0
+		for _, amount := range []string{"100", "101.5", "109.99", "110"} {
+			r, err := EstimateRequest("EUR", "GBP", Decimal{}, MustParseDecimal(amount))
+			if err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+
+			e, err := c.Estimate(r)
+			if err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+
+			if amount == "101.5" {
+				o, _ := e.Cheapest()
+				if !e.SourceAmount.Equal(MustParseDecimal("101.5")) || o.TargetAmount.String() != "86.49" {
+					t.Errorf("expected the estimate to be repriced for 101.5, but got %v and %v", e.SourceAmount, o.TargetAmount)
+				}
+			}
+		}
+
+		if calls != 2 {
+			t.Errorf("expected 2 requests for 2 buckets, but got %d", calls)
+		}
+
+		c.Clear()
+		r, _ := EstimateRequest("EUR", "GBP", Decimal{}, NewDecimal(100, 0))
+		if _, err := c.Estimate(r); err != nil || calls != 3 {
+			t.Errorf("expected a request after clearing the cache, but got %d (%v)", calls, err)
+		}
+
+		e := Estimate{SourceCurrency: "EUR", TargetCurrency: "GBP", Rate: MustParseDecimal("0.8574"), PaymentOptions: []PaymentOption{{Fee: QuoteFee{Total: MustParseDecimal("0.62")}}}}
+		if o := e.reprice(MustParseDecimal("85.21"), true).PaymentOptions[0]; o.SourceAmount.String() != "100.00" || o.TargetAmount.String() != "85.21" {
+			t.Errorf("expected 100.00 EUR for 85.21 GBP, but got %v and %v", o.SourceAmount, o.TargetAmount)
+		}
+
+		if _, err := NewEstimateCache(api, time.Minute, Decimal{}); err == nil {
+			t.Errorf("expected an error for an empty bucket size")
+		}
+	})
+}
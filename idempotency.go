@@ -0,0 +1,216 @@
+package transferwise
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithIdempotencyKey sets the key Wise uses to deduplicate a mutating request,
+// which also makes it safe to retry and, with an IdempotencyStore, to replay.
+// Creating transfers, converting balances and funding transfers always send a
+// key, derived from the request when the caller doesn't supply one. Other
+// calls, such as creating recipients, addresses or profiles, are only
+// protected against duplicates when the caller passes this option.
+func WithIdempotencyKey(key string) ReqOption {
+	return func(r *http.Request) error {
+		if key == "" {
+			return fmt.Errorf("idempotency key can't be empty")
+		}
+
+		r.Header.Set(idempotenceHeader, key)
+		return nil
+	}
+}
+
+// derivedKey returns a stable UUID formatted key for a request that can only
+// succeed once, such as converting a quote, so retries and replays reuse it.
+func derivedKey(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "/")))
+	b := h[:16]
+	b[6] = (b[6] & 0x0f) | 0x50
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+type IdempotencyRecord struct {
+	Key       string    `json:"key"`
+	Method    string    `json:"method"`
+	URL       string    `json:"url"`
+	Request   []byte    `json:"request"`
+	Response  []byte    `json:"response"`
+	Completed bool      `json:"completed"`
+	Created   time.Time `json:"created"`
+}
+
+func (r IdempotencyRecord) matches(url, method string, body []byte) bool {
+	return r.URL == url && r.Method == method && bytes.Equal(r.Request, body)
+}
+
+type IdempotencyStore interface {
+	// Load returns the record for key, or nil if there is none.
+	Load(key string) (*IdempotencyRecord, error)
+	Save(r IdempotencyRecord) error
+}
+
+func WithIdempotencyStore(s IdempotencyStore) APIOption {
+	return func(a *API) error {
+		if s == nil {
+			return fmt.Errorf("idempotency store can't be nil")
+		}
+
+		a.store = s
+		return nil
+	}
+}
+
+func (a *API) checkIdempotency(key, url, method string, body []byte) (*IdempotencyRecord, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+
+	rec, err := a.store.Load(key)
+	if err != nil {
+		return nil, &RequestError{Op: "idempotency store", Err: err}
+	}
+
+	if rec != nil {
+		if !rec.matches(url, method, body) {
+			return nil, fmt.Errorf("idempotency key %s was already used for another request", key)
+		}
+		return rec, nil
+	}
+
+	r := IdempotencyRecord{
+		Key:     key,
+		Method:  method,
+		URL:     url,
+		Request: body,
+		Created: time.Now(),
+	}
+
+	if err := a.store.Save(r); err != nil {
+		return nil, &RequestError{Op: "idempotency store", Err: err}
+	}
+
+	return nil, nil
+}
+
+func (a *API) completeIdempotency(key, url, method string, body, response []byte) error {
+	if a.store == nil {
+		return nil
+	}
+
+	r := IdempotencyRecord{
+		Key:       key,
+		Method:    method,
+		URL:       url,
+		Request:   body,
+		Response:  response,
+		Completed: true,
+		Created:   time.Now(),
+	}
+
+	if err := a.store.Save(r); err != nil {
+		return &RequestError{Op: "idempotency store", Err: err}
+	}
+
+	return nil
+}
+
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: map[string]IdempotencyRecord{}}
+}
+
+func (s *MemoryIdempotencyStore) Load(key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &r, nil
+}
+
+func (s *MemoryIdempotencyStore) Save(r IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[r.Key] = r
+	return nil
+}
+
+type FileIdempotencyStore struct {
+	dir string
+}
+
+func NewFileIdempotencyStore(dir string) (*FileIdempotencyStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating idempotency store: %w", err)
+	}
+
+	return &FileIdempotencyStore{dir: dir}, nil
+}
+
+func (s *FileIdempotencyStore) path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (s *FileIdempotencyStore) Load(key string) (*IdempotencyRecord, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := IdempotencyRecord{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (s *FileIdempotencyStore) Save(r IdempotencyRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial record.
+	tmp := s.path(r.Key) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path(r.Key))
+}
@@ -0,0 +1,180 @@
+package transferwise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIdempotency(t *testing.T) {
+	var calls int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.Method == http.MethodPost && r.Header.Get(idempotenceHeader) == "" {
+			t.Errorf("expected an idempotency key on %s %s", r.Method, r.URL)
+		}
+
+		if n == 1 {
+			w.Write([]byte(`{"id":1,"status":"incoming_payment_waiting"}`))
+			return
+		}
+		w.Write([]byte(`{"id":2,"status":"incoming_payment_waiting"}`))
+	})
+
+	req, err := QuoteResponse{ID: 42}.TransferRequest(13, "4ee4d7a1-9b1b-4e7f-9b58-3b7e19d1a0f6", TransferDetails{})
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	t.Run("noKey", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if k := r.Header.Get(idempotenceHeader); k != "" {
+				t.Errorf("expected no generated idempotency key, but got %v", k)
+			}
+			w.Write([]byte(`{"id":1}`))
+		}), WithIdempotencyStore(store))
+
+		if err := api.do(context.Background(), "v1/accounts", http.MethodPost, nil, nil); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(store.records) != 0 {
+			t.Errorf("expected no records without a key, but got %d", len(store.records))
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		dir := t.TempDir()
+
+		s, err := NewFileIdempotencyStore(dir)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		api := newTestAPI(t, h, WithIdempotencyStore(s))
+		tr, err := api.CreateTransfer(req)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		// Simulate a restart by creating a new client on the same store.
+		s, _ = NewFileIdempotencyStore(dir)
+		api = newTestAPI(t, h, WithIdempotencyStore(s))
+		replayed, err := api.CreateTransfer(req)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if replayed.ID != tr.ID || calls != 1 {
+			t.Errorf("expected the original transfer %d without a new call, but got %d after %d calls", tr.ID, replayed.ID, calls)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		api := newTestAPI(t, h, WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		if _, err := api.CreateTransfer(req); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		other := req
+		other.TargetAccount = 14
+		if _, err := api.CreateTransfer(other); err == nil {
+			t.Errorf("expected an error when reusing a key for another request")
+		}
+	})
+
+	t.Run("callerKey", func(t *testing.T) {
+		var calls, tokens int32
+		store := NewMemoryIdempotencyStore()
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			if k := r.Header.Get(idempotenceHeader); k != "recipient-1" {
+				t.Errorf("expected the caller's idempotency key, but got %q", k)
+			}
+			w.Write([]byte(`{"id":7}`))
+		}), WithIdempotencyStore(store), WithTokenSource(countingTokenSource{&tokens}))
+
+		r, err := profile{ID: 1}.RecipientRequest("EUR", "Jane Doe", EmailDetails{Email: "jane@example.com"})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			rec, err := api.CreateRecipient(r, WithIdempotencyKey("recipient-1"))
+			if err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+
+			if rec.ID != 7 {
+				t.Errorf("expected recipient 7, but got %d", rec.ID)
+			}
+		}
+
+		if calls != 1 || tokens != 1 {
+			t.Errorf("expected 1 call and 1 token fetch, but got %d calls and %d token fetches", calls, tokens)
+		}
+	})
+
+	t.Run("callerOption", func(t *testing.T) {
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("reason") != "duplicate" {
+				t.Errorf("expected the option's query parameter, but got %v", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"id":1,"status":"cancelled"}`))
+		}))
+
+		applied := 0
+		withReason := func(r *http.Request) error {
+			applied++
+			q := r.URL.Query()
+			q.Set("reason", "duplicate")
+			r.URL.RawQuery = q.Encode()
+			return nil
+		}
+
+		if _, err := api.CancelTransfer(1, withReason); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if applied != 1 {
+			t.Errorf("expected the option to be applied once, but got %d", applied)
+		}
+	})
+
+	t.Run("storeError", func(t *testing.T) {
+		api := newTestAPI(t, h, WithIdempotencyStore(failingStore{}))
+
+		var re *RequestError
+		if _, err := api.CreateTransfer(req); !errors.Is(err, errStoreDown) || !errors.As(err, &re) {
+			t.Errorf("expected the store error to be wrapped, but got %v", err)
+		}
+	})
+
+	t.Run("emptyKey", func(t *testing.T) {
+		api := newTestAPI(t, h)
+		if err := api.do(context.Background(), "v1/transfers", http.MethodPost, nil, nil, WithIdempotencyKey("")); err == nil {
+			t.Errorf("expected an error for an empty key")
+		}
+	})
+}
+
+type countingTokenSource struct {
+	n *int32
+}
+
+func (s countingTokenSource) Token(ctx context.Context) (*Token, error) {
+	atomic.AddInt32(s.n, 1)
+	return &Token{AccessToken: "test-token"}, nil
+}
+
+var errStoreDown = errors.New("store down")
+
+type failingStore struct{}
+
+func (failingStore) Load(key string) (*IdempotencyRecord, error) { return nil, errStoreDown }
+
+func (failingStore) Save(r IdempotencyRecord) error { return errStoreDown }
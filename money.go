@@ -0,0 +1,291 @@
+package transferwise
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxScale and maxExponent bound the decimals and exponent ParseDecimal
+	// accepts, so a short string can't expand into a huge number.
+	maxScale    = 64
+	maxExponent = 64
+)
+
+var bigTen = big.NewInt(10)
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// Decimal is an exact base 10 number, stored as an arbitrary precision integer
+// value and the number of digits after the decimal point. Arithmetic never
+// overflows. The zero value is 0.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// int returns the value of d, which must not be modified.
+func (d Decimal) int() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	orig := s
+	if s == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+	}
+
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+		}
+
+		if e < -maxExponent || e > maxExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", orig)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	scale := int64(0)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+
+	if s == "" || s == "-" || s == "+" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+	}
+
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+	}
+
+	scale -= exp
+	if scale < 0 {
+		v.Mul(v, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	if scale > maxScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: too many decimals", orig)
+	}
+
+	return Decimal{value: v, scale: int32(scale)}, nil
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat converts f using the shortest representation that
+// round-trips, so 0.1 becomes exactly 0.1.
+func DecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+func (d Decimal) rescale(scale int32) Decimal {
+	if d.scale >= scale {
+		return d
+	}
+
+	v := new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+	return Decimal{value: v, scale: scale}
+}
+
+func align(a, b Decimal) (Decimal, Decimal) {
+	if a.scale < b.scale {
+		return a.rescale(b.scale), b
+	}
+	return a, b.rescale(a.scale)
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	d, o = align(d, o)
+	return Decimal{value: new(big.Int).Add(d.int(), o.int()), scale: d.scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return d.Add(o.Neg())
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// quo divides d by o, rounding half away from zero to the given number of
+// decimals. o must not be zero.
+func (d Decimal) quo(o Decimal, places int32) Decimal {
+	n := new(big.Int).Mul(d.int(), pow10(o.scale+places+1))
+	m := new(big.Int).Mul(o.int(), pow10(d.scale))
+	return Decimal{value: n.Quo(n, m), scale: places + 1}.Round(places)
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	d, o = align(d, o)
+	return d.int().Cmp(o.int())
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round rounds half away from zero to the given number of decimals.
+func (d Decimal) Round(places int32) Decimal {
+	if d.scale <= places {
+		return d
+	}
+
+	p := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.int(), p, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(p) >= 0 {
+		if d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return Decimal{value: q, scale: places}
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	s := d.int().String()
+	if d.scale <= 0 {
+		return s
+	}
+
+	sign := ""
+	if d.Sign() < 0 {
+		sign, s = "-", s[1:]
+	}
+
+	if l := int(d.scale) + 1; len(s) < l {
+		s = strings.Repeat("0", l-len(s)) + s
+	}
+
+	i := len(s) - int(d.scale)
+	return sign + s[:i] + "." + s[i:]
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), "\"")
+	if s == "null" || s == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
+
+func MinorUnits(currency string) int32 {
+	return Currency(currency).MinorUnits()
+}
+
+type Amount struct {
+	Value    Decimal `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+func NewAmount(value Decimal, currency string) Amount {
+	return Amount{Value: value, Currency: currency}
+}
+
+func AmountFromMinorUnits(units int64, currency string) Amount {
+	return Amount{Value: NewDecimal(units, MinorUnits(currency)), Currency: currency}
+}
+
+func (a Amount) sameCurrency(b Amount) error {
+	if a.Currency != b.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", a.Currency, b.Currency)
+	}
+	return nil
+}
+
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{Value: a.Value.Add(b.Value), Currency: a.Currency}, nil
+}
+
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{Value: a.Value.Sub(b.Value), Currency: a.Currency}, nil
+}
+
+func (a Amount) Cmp(b Amount) (int, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return 0, err
+	}
+	return a.Value.Cmp(b.Value), nil
+}
+
+func (a Amount) Round() Amount {
+	return Amount{Value: a.Value.Round(MinorUnits(a.Currency)), Currency: a.Currency}
+}
+
+// MinorUnits returns the rounded amount in minor units, or an error if it
+// doesn't fit in an int64.
+func (a Amount) MinorUnits() (int64, error) {
+	u := a.Value.Round(MinorUnits(a.Currency)).rescale(MinorUnits(a.Currency)).int()
+	if !u.IsInt64() {
+		return 0, fmt.Errorf("%v has too many minor units", a)
+	}
+	return u.Int64(), nil
+}
+
+func (a Amount) String() string {
+	return fmt.Sprintf("%s %s", a.Round().Value.rescale(MinorUnits(a.Currency)), a.Currency)
+}
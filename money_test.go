@@ -0,0 +1,185 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		tests := map[string]string{
+			"100":       "100",
+			"0.1":       "0.1",
+			"-12.345":   "-12.345",
+			"0.005":     "0.005",
+			"1.5e2":     "150",
+			"1.2E-5":    "0.000012",
+			"-0.50":     "-0.50",
+			"\"10.01\"": "10.01",
+			"1e25":      "10000000000000000000000000",
+		}
+
+		for in, want := range tests {
+			d := Decimal{}
+			if err := json.Unmarshal([]byte(in), &d); err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+
+			if d.String() != want {
+				t.Errorf("expected %s for %s, but got %s", want, in, d)
+			}
+		}
+
+		for _, in := range []string{"", "-", "1.2.3", "abc", "1e", "1e1000000"} {
+			if _, err := ParseDecimal(in); err == nil {
+				t.Errorf("expected %q to fail", in)
+			}
+		}
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		if s := MustParseDecimal("0.1").Add(MustParseDecimal("0.2")); !s.Equal(MustParseDecimal("0.3")) {
+			t.Errorf("expected 0.3, but got %v", s)
+		}
+
+		if s := MustParseDecimal("10").Sub(MustParseDecimal("0.01")); s.String() != "9.99" {
+			t.Errorf("expected 9.99, but got %v", s)
+		}
+
+		if m := MustParseDecimal("100.5").Mul(MustParseDecimal("0.88")); !m.Equal(MustParseDecimal("88.44")) {
+			t.Errorf("expected 88.44, but got %v", m)
+		}
+
+		if c := MustParseDecimal("1.50").Cmp(MustParseDecimal("1.5")); c != 0 {
+			t.Errorf("expected 1.50 to equal 1.5, but got %d", c)
+		}
+
+		if s := MustParseDecimal("92233720368547758.07").Add(MustParseDecimal("0.001")); s.String() != "92233720368547758.071" {
+			t.Errorf("expected 92233720368547758.071, but got %v", s)
+		}
+
+		if m := MustParseDecimal("9223372036854775807").Mul(MustParseDecimal("10")); m.String() != "92233720368547758070" {
+			t.Errorf("expected 92233720368547758070, but got %v", m)
+		}
+	})
+
+	t.Run("round", func(t *testing.T) {
+		tests := []struct {
+			in     string
+			places int32
+			want   string
+		}{
+			{"1.005", 2, "1.01"},
+			{"1.004", 2, "1.00"},
+			{"-1.005", 2, "-1.01"},
+			{"1234.5", 0, "1235"},
+			{"1.5", 3, "1.5"},
+			{"1.000000000110000000001", 2, "1.00"},
+			{"-0.0000000000000000000005", 21, "-0.000000000000000000001"},
+		}
+
+		for _, tc := range tests {
+			if r := MustParseDecimal(tc.in).Round(tc.places); r.String() != tc.want {
+				t.Errorf("expected %s for %s, but got %s", tc.want, tc.in, r)
+			}
+		}
+
+		if r := MustParseDecimal("1.0000000001").Mul(MustParseDecimal("1.00000000001")).Round(2); r.String() != "1.00" {
+			t.Errorf("expected 1.00, but got %s", r)
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		if d := DecimalFromFloat(600.1); d.String() != "600.1" {
+			t.Errorf("expected 600.1, but got %v", d)
+		}
+
+		if d := DecimalFromFloat(1.0 / 3); d.String() != "0.3333333333333333" {
+			t.Errorf("expected 0.3333333333333333, but got %v", d)
+		}
+
+		if f := MustParseDecimal("9998.5").Float64(); f != 9998.5 {
+			t.Errorf("expected 9998.5, but got %v", f)
+		}
+	})
+
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(Amount{Value: MustParseDecimal("1234.10"), Currency: "EUR"})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if string(b) != `{"value":1234.10,"currency":"EUR"}` {
+			t.Errorf("unexpected JSON: %s", b)
+		}
+	})
+}
+
+func TestAmount(t *testing.T) {
+	t.Run("minor units", func(t *testing.T) {
+		tests := []struct {
+			a     Amount
+			units int64
+			str   string
+		}{
+			{NewAmount(MustParseDecimal("1234.567"), "JPY"), 1235, "1235 JPY"},
+			{NewAmount(MustParseDecimal("1.2345"), "BHD"), 1235, "1.235 BHD"},
+			{NewAmount(MustParseDecimal("10"), "EUR"), 1000, "10.00 EUR"},
+		}
+
+		for _, tc := range tests {
+			if u, err := tc.a.MinorUnits(); err != nil || u != tc.units {
+				t.Errorf("expected %d minor units for %v, but got %d and %v", tc.units, tc.a.Value, u, err)
+			}
+
+			if s := tc.a.String(); s != tc.str {
+				t.Errorf("expected %s, but got %s", tc.str, s)
+			}
+		}
+
+		if _, err := NewAmount(MustParseDecimal("92233720368547758.08"), "EUR").MinorUnits(); err == nil {
+			t.Errorf("expected an error for minor units beyond int64")
+		}
+
+		if a := AmountFromMinorUnits(1999, "KWD"); a.Value.String() != "1.999" {
+			t.Errorf("expected 1.999, but got %v", a.Value)
+		}
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		a := NewAmount(MustParseDecimal("100.10"), "EUR")
+		s, err := a.Sub(NewAmount(MustParseDecimal("0.30"), "EUR"))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if c, _ := s.Cmp(NewAmount(MustParseDecimal("99.8"), "EUR")); c != 0 {
+			t.Errorf("expected 99.80 EUR, but got %v", s)
+		}
+
+		if _, err := a.Add(NewAmount(MustParseDecimal("1"), "GBP")); err == nil {
+			t.Errorf("expected a currency mismatch error")
+		}
+	})
+
+	t.Run("quote request", func(t *testing.T) {
+		p := profile{ID: 1}
+		q, err := p.QuoteRequest("EUR", "GBP", None, 0.1, BalancePayout)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		b, err := json.Marshal(q)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if string(b) != `{"profile":1,"source":"EUR","target":"GBP","rateType":"FIXED","sourceAmount":0.1,"type":"BALANCE_PAYOUT"}` {
+			t.Errorf("unexpected JSON: %s", b)
+		}
+
+		if _, err := p.QuoteRequestDecimal("EUR", "GBP", NewDecimal(1, 0), NewDecimal(1, 0), BalancePayout); err == nil {
+			t.Errorf("expected an error when both amounts are set")
+		}
+	})
+}
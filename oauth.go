@@ -0,0 +1,242 @@
+package transferwise
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	urlpkg "net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expiryDelta is how long before the actual expiry a token is considered
+// expired, so that it isn't used while it expires in transit.
+const expiryDelta = 30 * time.Second
+
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int       `json:"expires_in"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	return t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+type staticTokenSource struct {
+	t *Token
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.t, nil
+}
+
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource{t: &Token{AccessToken: token, TokenType: "bearer"}}
+}
+
+func WithTokenSource(ts TokenSource) APIOption {
+	return func(a *API) error {
+		if ts == nil {
+			return fmt.Errorf("token source can't be nil")
+		}
+
+		a.tokens = ts
+		return nil
+	}
+}
+
+type OAuthClient struct {
+	url          string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+}
+
+type OAuthOption func(*OAuthClient) error
+
+func WithOAuthURL(url string) OAuthOption {
+	return func(c *OAuthClient) error {
+		c.url = url
+		return nil
+	}
+}
+
+func WithOAuthSandbox() OAuthOption {
+	return WithOAuthURL(sandboxURL)
+}
+
+func WithOAuthHTTPClient(hc *http.Client) OAuthOption {
+	return func(c *OAuthClient) error {
+		if hc == nil {
+			return fmt.Errorf("http client can't be nil")
+		}
+
+		c.client = hc
+		return nil
+	}
+}
+
+func NewOAuthClient(clientID, clientSecret string, options ...OAuthOption) (*OAuthClient, error) {
+	c := OAuthClient{
+		url:          url,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       defaultClient,
+	}
+
+	for _, opt := range options {
+		if err := opt(&c); err != nil {
+			return nil, fmt.Errorf("option error: %v", err)
+		}
+	}
+
+	return &c, nil
+}
+
+func (c *OAuthClient) token(ctx context.Context, form urlpkg.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, &RequestError{Op: "request creation", Err: err}
+	}
+
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, &RequestError{Op: "client", Err: err}
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, &RequestError{Op: "response reading", Err: err}
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode > http.StatusIMUsed {
+		return nil, newAPIError(res, raw)
+	}
+
+	t := Token{}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, &RequestError{Op: "json decoding", Err: err}
+	}
+
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+
+	return &t, nil
+}
+
+func (c *OAuthClient) ClientCredentials(ctx context.Context) (*Token, error) {
+	form := urlpkg.Values{}
+	form.Set("grant_type", "client_credentials")
+	return c.token(ctx, form)
+}
+
+func (c *OAuthClient) Exchange(ctx context.Context, code, redirectURI string) (*Token, error) {
+	form := urlpkg.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", c.clientID)
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	return c.token(ctx, form)
+}
+
+func (c *OAuthClient) RegistrationCode(ctx context.Context, email, registrationCode string) (*Token, error) {
+	form := urlpkg.Values{}
+	form.Set("grant_type", "registration_code")
+	form.Set("client_id", c.clientID)
+	form.Set("email", email)
+	form.Set("registration_code", registrationCode)
+	return c.token(ctx, form)
+}
+
+func (c *OAuthClient) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	form := urlpkg.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	t, err := c.token(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.RefreshToken == "" {
+		t.RefreshToken = refreshToken
+	}
+
+	return t, nil
+}
+
+type refreshingTokenSource struct {
+	fetch   func(ctx context.Context, t *Token) (*Token, error)
+	persist func(*Token) error
+
+	mu      sync.Mutex
+	t       *Token
+	unsaved bool
+}
+
+func (s *refreshingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.t.Valid() {
+		t, err := s.fetch(ctx, s.t)
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing token: %w", err)
+		}
+
+		// Keep the new token even if persisting it fails, as the refresh token
+		// may have been rotated and the old one can't be used anymore.
+		s.t = t
+		s.unsaved = s.persist != nil
+	}
+
+	if s.unsaved {
+		if err := s.persist(s.t); err != nil {
+			return nil, fmt.Errorf("error persisting token: %w", err)
+		}
+		s.unsaved = false
+	}
+
+	return s.t, nil
+}
+
+func (c *OAuthClient) ClientCredentialsTokenSource(persist func(*Token) error) TokenSource {
+	return &refreshingTokenSource{
+		fetch: func(ctx context.Context, _ *Token) (*Token, error) {
+			return c.ClientCredentials(ctx)
+		},
+		persist: persist,
+	}
+}
+
+func (c *OAuthClient) TokenSource(t *Token, persist func(*Token) error) TokenSource {
+	return &refreshingTokenSource{
+		fetch: func(ctx context.Context, t *Token) (*Token, error) {
+			if t == nil || t.RefreshToken == "" {
+				return nil, fmt.Errorf("%w: token expired and no refresh token available", ErrUnauthorized)
+			}
+			return c.Refresh(ctx, t.RefreshToken)
+		},
+		persist: persist,
+		t:       t,
+	}
+}
@@ -0,0 +1,196 @@
+package transferwise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type oauthStandIn struct {
+	t        *testing.T
+	issued   int32
+	lifetime int
+}
+
+func (o *oauthStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/oauth/token" {
+		if r.Header.Get("Authorization") == "Bearer " {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`[]`))
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != "client" || secret != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","error_description":"Full authentication is required"}`))
+		return
+	}
+
+	r.ParseForm()
+	switch r.PostForm.Get("grant_type") {
+	case "client_credentials":
+	case "authorization_code":
+		if r.PostForm.Get("code") != "code" || r.PostForm.Get("redirect_uri") != "https://example.com/cb" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid authorization code"}`))
+			return
+		}
+	case "refresh_token":
+		if r.PostForm.Get("refresh_token") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	default:
+		o.t.Errorf("unexpected grant type %v", r.PostForm.Get("grant_type"))
+	}
+
+	n := atomic.AddInt32(&o.issued, 1)
+	fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"bearer","refresh_token":"refresh-%d","expires_in":%d,"scope":"transfers"}`, n, n, o.lifetime)
+}
+
+func TestOAuth(t *testing.T) {
+	newClient := func(t *testing.T, lifetime int) (*oauthStandIn, *API, *OAuthClient) {
+		o := &oauthStandIn{t: t, lifetime: lifetime}
+		api := newTestAPI(t, o)
+
+		c, err := NewOAuthClient("client", "secret", WithOAuthURL(api.url))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		return o, api, c
+	}
+
+	t.Run("clientCredentials", func(t *testing.T) {
+		o, api, c := newClient(t, 3600)
+
+		persisted := []*Token{}
+		ts := c.ClientCredentialsTokenSource(func(t *Token) error {
+			persisted = append(persisted, t)
+			return nil
+		})
+
+		if err := WithTokenSource(ts)(api); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := api.Profiles(); err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+		}
+
+		if o.issued != 1 || len(persisted) != 1 {
+			t.Errorf("expected one token to be issued and persisted, but got %d and %d", o.issued, len(persisted))
+		}
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		_, _, c := newClient(t, 3600)
+
+		tok, err := c.Exchange(context.Background(), "code", "https://example.com/cb")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if tok.AccessToken != "access-1" || tok.RefreshToken != "refresh-1" || !tok.Valid() {
+			t.Errorf("unexpected token: %#v", tok)
+		}
+
+		_, err = c.Exchange(context.Background(), "wrong", "https://example.com/cb")
+
+		var ae APIError
+		if !errors.As(err, &ae) || ae.Code != "invalid_grant" {
+			t.Errorf("expected an invalid_grant error, but got %v", err)
+		}
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		o, api, c := newClient(t, 3600)
+
+		persisted := int32(0)
+		expired := &Token{AccessToken: "old", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)}
+		ts := c.TokenSource(expired, func(t *Token) error {
+			atomic.AddInt32(&persisted, 1)
+			return nil
+		})
+		WithTokenSource(ts)(api)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := api.Profiles(); err != nil {
+					t.Errorf("expected to pass, but got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if o.issued != 1 || persisted != 1 {
+			t.Errorf("expected concurrent callers to share one refresh, but got %d refreshes and %d persisted", o.issued, persisted)
+		}
+
+		tok, _ := ts.Token(context.Background())
+		if tok.AccessToken != "access-1" || tok.RefreshToken != "refresh-1" {
+			t.Errorf("expected the rotated token, but got %#v", tok)
+		}
+	})
+
+	t.Run("persistFailure", func(t *testing.T) {
+		o, _, c := newClient(t, 3600)
+
+		fail := true
+		saved := ""
+		expired := &Token{AccessToken: "old", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)}
+		ts := c.TokenSource(expired, func(t *Token) error {
+			if fail {
+				return errors.New("disk full")
+			}
+			saved = t.RefreshToken
+			return nil
+		})
+
+		if _, err := ts.Token(context.Background()); err == nil {
+			t.Errorf("expected the persist error")
+		}
+
+		fail = false
+		tok, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if o.issued != 1 || tok.RefreshToken != "refresh-1" || saved != "refresh-1" {
+			t.Errorf("expected the rotated token to be kept and saved, but got %d refreshes, %#v and %q saved", o.issued, tok, saved)
+		}
+	})
+
+	t.Run("noRefreshToken", func(t *testing.T) {
+		_, api, c := newClient(t, 3600)
+		WithTokenSource(c.TokenSource(nil, nil))(api)
+
+		if _, err := api.Profiles(); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("expected unauthorized, but got %v", err)
+		}
+	})
+
+	t.Run("badCredentials", func(t *testing.T) {
+		o := &oauthStandIn{t: t}
+		api := newTestAPI(t, o)
+		c, _ := NewOAuthClient("client", "wrong", WithOAuthURL(api.url))
+
+		if _, err := c.ClientCredentials(context.Background()); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("expected unauthorized, but got %v", err)
+		}
+	})
+}
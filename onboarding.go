@@ -0,0 +1,204 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type User struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Active bool   `json:"active"`
+}
+
+type signupRequest struct {
+	Email            string   `json:"email"`
+	RegistrationCode string   `json:"registrationCode"`
+	Language         Language `json:"language,omitempty"`
+}
+
+func (a *API) SignUpUser(email, registrationCode string, l Language, options ...ReqOption) (*User, error) {
+	return a.SignUpUserContext(context.Background(), email, registrationCode, l, options...)
+}
+
+func (a *API) SignUpUserContext(ctx context.Context, email, registrationCode string, l Language, options ...ReqOption) (*User, error) {
+	u := User{}
+	req := signupRequest{
+		Email:            email,
+		RegistrationCode: registrationCode,
+		Language:         l,
+	}
+
+	if err := a.do(ctx, "v1/user/signup/registration_code", http.MethodPost, req, &u, options...); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+type OnboardingDocument struct {
+	Type    DocumentType
+	Number  string
+	Issued  time.Time
+	Country string
+	State   string
+	Expires time.Time
+}
+
+type OnboardingRequest struct {
+	Email            string
+	RegistrationCode string
+	Language         Language
+	Personal         *PersonalProfileRequest
+	Business         *BusinessProfileRequest
+	Address          *AddressDetails
+	Documents        []OnboardingDocument
+	// OnProgress is called with the updated state after every completed step,
+	// so it can be persisted and the onboarding resumed after a failure.
+	OnProgress func(OnboardingState) error
+}
+
+type OnboardingState struct {
+	UserID    int    `json:"userId"`
+	Token     *Token `json:"token"`
+	ProfileID int    `json:"profileId"`
+	AddressID int    `json:"addressId"`
+	Documents int    `json:"documents"`
+}
+
+func (r OnboardingRequest) validate() error {
+	if r.Email == "" || r.RegistrationCode == "" {
+		return fmt.Errorf("email and registration code are required")
+	}
+
+	if (r.Personal == nil) == (r.Business == nil) {
+		return fmt.Errorf("specify either a personal or a business profile")
+	}
+
+	if len(r.Documents) > 0 && r.Personal == nil {
+		return fmt.Errorf("verification documents can only be submitted for personal profiles")
+	}
+
+	return nil
+}
+
+func (a *API) withTokenSource(ts TokenSource) *API {
+	c := *a
+	c.tokens = ts
+	return &c
+}
+
+func (a *API) Onboard(c *OAuthClient, r OnboardingRequest, s *OnboardingState) (*OnboardingState, error) {
+	return a.OnboardContext(context.Background(), c, r, s)
+}
+
+// OnboardContext creates the user, profile, address and documents, skipping the
+// steps already recorded in s. A nil s starts a fresh onboarding. It returns the
+// state with the created IDs, also when a step fails, so the onboarding can be
+// resumed. s is updated while tokens are refreshed, so it must not be shared
+// with other goroutines during the call.
+func (a *API) OnboardContext(ctx context.Context, c *OAuthClient, r OnboardingRequest, s *OnboardingState) (*OnboardingState, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		s = &OnboardingState{}
+	}
+
+	progress := func() error {
+		if r.OnProgress == nil {
+			return nil
+		}
+
+		if err := r.OnProgress(*s); err != nil {
+			return fmt.Errorf("error saving onboarding progress: %w", err)
+		}
+		return nil
+	}
+
+	if s.UserID == 0 {
+		u, err := a.SignUpUserContext(ctx, r.Email, r.RegistrationCode, r.Language)
+		if err != nil {
+			return s, fmt.Errorf("error creating user: %w", err)
+		}
+
+		s.UserID = u.ID
+		if err := progress(); err != nil {
+			return s, err
+		}
+	}
+
+	if s.Token == nil {
+		t, err := c.RegistrationCode(ctx, r.Email, r.RegistrationCode)
+		if err != nil {
+			return s, fmt.Errorf("error obtaining user token: %w", err)
+		}
+
+		s.Token = t
+		if err := progress(); err != nil {
+			return s, err
+		}
+	}
+
+	user := a.withTokenSource(c.TokenSource(s.Token, func(t *Token) error {
+		s.Token = t
+		return progress()
+	}))
+
+	if s.ProfileID == 0 {
+		var err error
+		if r.Personal != nil {
+			var p *Person
+			if p, err = user.CreatePersonalProfileContext(ctx, *r.Personal); err == nil {
+				s.ProfileID = p.ID
+			}
+		} else {
+			var b *Business
+			if b, err = user.CreateBusinessProfileContext(ctx, *r.Business); err == nil {
+				s.ProfileID = b.ID
+			}
+		}
+
+		if err != nil {
+			return s, fmt.Errorf("error creating profile: %w", err)
+		}
+
+		if err := progress(); err != nil {
+			return s, err
+		}
+	}
+
+	if r.Address != nil && s.AddressID == 0 {
+		addr, err := user.CreateAddressContext(ctx, s.ProfileID, *r.Address)
+		if err != nil {
+			return s, fmt.Errorf("error creating address: %w", err)
+		}
+
+		s.AddressID = addr.ID
+		if err := progress(); err != nil {
+			return s, err
+		}
+	}
+
+	for s.Documents < len(r.Documents) {
+		d := r.Documents[s.Documents]
+
+		p := Person{ID: s.ProfileID}
+		p.Details.FirstName = r.Personal.FirstName
+		p.Details.LastName = r.Personal.LastName
+
+		if err := user.VerificationDocumentContext(ctx, &p, d.Type, d.Number, d.Issued, d.Country, d.State, d.Expires); err != nil {
+			return s, fmt.Errorf("error submitting verification document %d: %w", s.Documents, err)
+		}
+
+		s.Documents++
+		if err := progress(); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,138 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOnboard(t *testing.T) {
+	calls := map[string]int{}
+	failAddress := true
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		auth := r.Header.Get("Authorization")
+
+		switch r.URL.Path {
+		case "/oauth/token":
+			r.ParseForm()
+			if r.PostForm.Get("grant_type") != "registration_code" || r.PostForm.Get("email") != "ann@example.com" {
+				t.Errorf("unexpected token request: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"access_token":"user-token","refresh_token":"user-refresh","expires_in":3600}`))
+		case "/v1/user/signup/registration_code":
+			if auth != "Bearer partner-token" {
+				t.Errorf("expected partner token, but got %v", auth)
+			}
+
+			req := signupRequest{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.RegistrationCode != "abcdefghijk" {
+				t.Errorf("unexpected signup request: %#v", req)
+			}
+			w.Write([]byte(`{"id":12345,"email":"ann@example.com","active":true}`))
+		case "/v1/profiles":
+			if auth != "Bearer user-token" {
+				t.Errorf("expected user token, but got %v", auth)
+			}
+			w.Write([]byte(`{"id":220,"type":"personal","details":{"firstName":"Ann"}}`))
+		case "/v1/addresses":
+			if failAddress {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			req := addressRequest{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Profile != 220 || req.Details.Country != "GB" {
+				t.Errorf("unexpected address request: %#v", req)
+			}
+			w.Write([]byte(`{"id":330,"profile":220}`))
+		case "/v1/profiles/220/verification-documents":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	c, err := NewOAuthClient("client", "secret", WithOAuthURL(api.url))
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	partner := api.withTokenSource(StaticTokenSource("partner-token"))
+
+	saved := OnboardingState{}
+	req := OnboardingRequest{
+		Email:            "ann@example.com",
+		RegistrationCode: "abcdefghijk",
+		Personal:         &PersonalProfileRequest{FirstName: "Ann", LastName: "Smith"},
+		Address:          &AddressDetails{Country: "GB", FirstLine: "56 Shoreditch High Street", PostCode: "E1 6JJ", City: "London"},
+		Documents: []OnboardingDocument{
+			{Type: Passport, Number: "123456789", Issued: time.Now().AddDate(-1, 0, 0), Country: "GB"},
+		},
+		OnProgress: func(s OnboardingState) error {
+			saved = s
+			return nil
+		},
+	}
+
+	t.Run("partial", func(t *testing.T) {
+		s := OnboardingState{}
+		got, err := partner.Onboard(c, req, &s)
+		if err == nil {
+			t.Fatalf("expected the address step to fail")
+		}
+
+		if got != &s || got.ProfileID != 220 {
+			t.Errorf("expected the partial state to be returned, but got %#v", got)
+		}
+
+		if saved.UserID != 12345 || saved.ProfileID != 220 || saved.AddressID != 0 || saved.Token == nil {
+			t.Errorf("unexpected saved state: %#v", saved)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		failAddress = false
+
+		s := saved
+		if _, err := partner.Onboard(c, req, &s); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if s.AddressID != 330 || s.Documents != 1 || saved.Documents != 1 {
+			t.Errorf("unexpected state: %#v", s)
+		}
+
+		for _, p := range []string{"/v1/user/signup/registration_code", "/oauth/token", "/v1/profiles"} {
+			if calls[p] != 1 {
+				t.Errorf("expected %s to be called once, but got %d", p, calls[p])
+			}
+		}
+	})
+
+	t.Run("nilState", func(t *testing.T) {
+		r := req
+		r.OnProgress = nil
+		s, err := partner.Onboard(c, r, nil)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if s.UserID != 12345 || s.ProfileID != 220 || s.AddressID != 330 || s.Documents != 1 {
+			t.Errorf("expected the created IDs to be returned, but got %#v", s)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := req
+		r.Business = &BusinessProfileRequest{Name: "Ltd"}
+		if _, err := partner.Onboard(c, r, &OnboardingState{}); err == nil {
+			t.Errorf("expected an error with both profile types")
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type BusinessDirector struct {
+	ID                 int    `json:"id,omitempty"`
+	FirstName          string `json:"firstName"`
+	LastName           string `json:"lastName"`
+	DateOfBirth        TwDate `json:"dateOfBirth"`
+	CountryOfResidence string `json:"countryOfResidence"`
+}
+
+type UBO struct {
+	ID                  string         `json:"id,omitempty"`
+	Name                string         `json:"name"`
+	DateOfBirth         TwDate         `json:"dateOfBirth"`
+	CountryOfBirth      string         `json:"countryOfBirth"`
+	HomeAddress         AddressDetails `json:"homeAddress"`
+	OwnershipPercentage float64        `json:"ownershipPercentage"`
+}
+
+type ShareholderType string
+
+var (
+	PersonShareholder   ShareholderType = "PERSON"
+	BusinessShareholder ShareholderType = "BUSINESS"
+)
+
+type Shareholder struct {
+	ID                  int             `json:"id,omitempty"`
+	Type                ShareholderType `json:"type"`
+	FirstName           string          `json:"firstName,omitempty"`
+	LastName            string          `json:"lastName,omitempty"`
+	BusinessName        string          `json:"businessName,omitempty"`
+	RegistrationNumber  string          `json:"registrationNumber,omitempty"`
+	OwnershipPercentage float64         `json:"ownershipPercentage"`
+	Address             *AddressDetails `json:"address,omitempty"`
+}
+
+// validateOwnership sums the percentages as decimals, so splits like 25.1,
+// 25.1, 25.1 and 24.7 add up to exactly 100.
+func validateOwnership(percentages []float64) error {
+	total := Decimal{}
+	for _, p := range percentages {
+		if p <= 0 || p > 100 {
+			return fmt.Errorf("ownership percentage should be between 0 and 100, but got %v", p)
+		}
+		total = total.Add(DecimalFromFloat(p))
+	}
+
+	if total.Cmp(NewDecimal(100, 0)) > 0 {
+		return fmt.Errorf("ownership percentages add up to %v%%, which is more than 100%%", total)
+	}
+
+	return nil
+}
+
+func uboPercentages(u []UBO) []float64 {
+	p := make([]float64, len(u))
+	for i := range u {
+		p[i] = u[i].OwnershipPercentage
+	}
+	return p
+}
+
+func shareholderPercentages(s []Shareholder) []float64 {
+	p := make([]float64, len(s))
+	for i := range s {
+		p[i] = s[i].OwnershipPercentage
+	}
+	return p
+}
+
+func (a *API) Directors(profileID int) ([]BusinessDirector, error) {
+	return a.DirectorsContext(context.Background(), profileID)
+}
+
+func (a *API) DirectorsContext(ctx context.Context, profileID int) ([]BusinessDirector, error) {
+	res := []BusinessDirector{}
+	url := fmt.Sprintf("v1/profiles/%d/directors", profileID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) AddDirectors(profileID int, d []BusinessDirector, options ...ReqOption) ([]BusinessDirector, error) {
+	return a.AddDirectorsContext(context.Background(), profileID, d, options...)
+}
+
+func (a *API) AddDirectorsContext(ctx context.Context, profileID int, d []BusinessDirector, options ...ReqOption) ([]BusinessDirector, error) {
+	res := []BusinessDirector{}
+	url := fmt.Sprintf("v1/profiles/%d/directors", profileID)
+	if err := a.do(ctx, url, http.MethodPost, d, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) UpdateDirectors(profileID int, d []BusinessDirector, options ...ReqOption) ([]BusinessDirector, error) {
+	return a.UpdateDirectorsContext(context.Background(), profileID, d, options...)
+}
+
+func (a *API) UpdateDirectorsContext(ctx context.Context, profileID int, d []BusinessDirector, options ...ReqOption) ([]BusinessDirector, error) {
+	res := []BusinessDirector{}
+	url := fmt.Sprintf("v1/profiles/%d/directors", profileID)
+	if err := a.do(ctx, url, http.MethodPut, d, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) RemoveDirector(profileID, id int, options ...ReqOption) error {
+	return a.RemoveDirectorContext(context.Background(), profileID, id, options...)
+}
+
+func (a *API) RemoveDirectorContext(ctx context.Context, profileID, id int, options ...ReqOption) error {
+	d, err := a.DirectorsContext(ctx, profileID)
+	if err != nil {
+		return err
+	}
+
+	kept := []BusinessDirector{}
+	for _, director := range d {
+		if director.ID != id {
+			kept = append(kept, director)
+		}
+	}
+
+	if len(kept) == len(d) {
+		return fmt.Errorf("%w: director %d", ErrNotFound, id)
+	}
+
+	_, err = a.UpdateDirectorsContext(ctx, profileID, kept, options...)
+	return err
+}
+
+func (a *API) UBOs(profileID int) ([]UBO, error) {
+	return a.UBOsContext(context.Background(), profileID)
+}
+
+func (a *API) UBOsContext(ctx context.Context, profileID int) ([]UBO, error) {
+	res := []UBO{}
+	url := fmt.Sprintf("v1/profiles/%d/ubos", profileID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) AddUBOs(profileID int, u []UBO, options ...ReqOption) ([]UBO, error) {
+	return a.AddUBOsContext(context.Background(), profileID, u, options...)
+}
+
+func (a *API) AddUBOsContext(ctx context.Context, profileID int, u []UBO, options ...ReqOption) ([]UBO, error) {
+	existing, err := a.UBOsContext(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateOwnership(append(uboPercentages(existing), uboPercentages(u)...)); err != nil {
+		return nil, err
+	}
+
+	res := []UBO{}
+	url := fmt.Sprintf("v1/profiles/%d/ubos", profileID)
+	if err := a.do(ctx, url, http.MethodPost, u, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) UpdateUBOs(profileID int, u []UBO, options ...ReqOption) ([]UBO, error) {
+	return a.UpdateUBOsContext(context.Background(), profileID, u, options...)
+}
+
+func (a *API) UpdateUBOsContext(ctx context.Context, profileID int, u []UBO, options ...ReqOption) ([]UBO, error) {
+	if err := validateOwnership(uboPercentages(u)); err != nil {
+		return nil, err
+	}
+
+	res := []UBO{}
+	url := fmt.Sprintf("v1/profiles/%d/ubos", profileID)
+	if err := a.do(ctx, url, http.MethodPut, u, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) RemoveUBO(profileID int, id string, options ...ReqOption) error {
+	return a.RemoveUBOContext(context.Background(), profileID, id, options...)
+}
+
+func (a *API) RemoveUBOContext(ctx context.Context, profileID int, id string, options ...ReqOption) error {
+	u, err := a.UBOsContext(ctx, profileID)
+	if err != nil {
+		return err
+	}
+
+	kept := []UBO{}
+	for _, ubo := range u {
+		if ubo.ID != id {
+			kept = append(kept, ubo)
+		}
+	}
+
+	if len(kept) == len(u) {
+		return fmt.Errorf("%w: ubo %s", ErrNotFound, id)
+	}
+
+	_, err = a.UpdateUBOsContext(ctx, profileID, kept, options...)
+	return err
+}
+
+func (a *API) Shareholders(profileID int) ([]Shareholder, error) {
+	return a.ShareholdersContext(context.Background(), profileID)
+}
+
+func (a *API) ShareholdersContext(ctx context.Context, profileID int) ([]Shareholder, error) {
+	res := []Shareholder{}
+	url := fmt.Sprintf("v1/profiles/%d/shareholders", profileID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) AddShareholders(profileID int, s []Shareholder, options ...ReqOption) ([]Shareholder, error) {
+	return a.AddShareholdersContext(context.Background(), profileID, s, options...)
+}
+
+func (a *API) AddShareholdersContext(ctx context.Context, profileID int, s []Shareholder, options ...ReqOption) ([]Shareholder, error) {
+	existing, err := a.ShareholdersContext(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateOwnership(append(shareholderPercentages(existing), shareholderPercentages(s)...)); err != nil {
+		return nil, err
+	}
+
+	res := []Shareholder{}
+	url := fmt.Sprintf("v1/profiles/%d/shareholders", profileID)
+	if err := a.do(ctx, url, http.MethodPost, s, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) UpdateShareholders(profileID int, s []Shareholder, options ...ReqOption) ([]Shareholder, error) {
+	return a.UpdateShareholdersContext(context.Background(), profileID, s, options...)
+}
+
+func (a *API) UpdateShareholdersContext(ctx context.Context, profileID int, s []Shareholder, options ...ReqOption) ([]Shareholder, error) {
+	if err := validateOwnership(shareholderPercentages(s)); err != nil {
+		return nil, err
+	}
+
+	res := []Shareholder{}
+	url := fmt.Sprintf("v1/profiles/%d/shareholders", profileID)
+	if err := a.do(ctx, url, http.MethodPut, s, &res, options...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) RemoveShareholder(profileID, id int, options ...ReqOption) error {
+	return a.RemoveShareholderContext(context.Background(), profileID, id, options...)
+}
+
+func (a *API) RemoveShareholderContext(ctx context.Context, profileID, id int, options ...ReqOption) error {
+	s, err := a.ShareholdersContext(ctx, profileID)
+	if err != nil {
+		return err
+	}
+
+	kept := []Shareholder{}
+	for _, shareholder := range s {
+		if shareholder.ID != id {
+			kept = append(kept, shareholder)
+		}
+	}
+
+	if len(kept) == len(s) {
+		return fmt.Errorf("%w: shareholder %d", ErrNotFound, id)
+	}
+
+	_, err = a.UpdateShareholdersContext(ctx, profileID, kept, options...)
+	return err
+}
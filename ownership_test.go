@@ -0,0 +1,112 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOwnership(t *testing.T) {
+	ubos := `[{"id":"013ab1c2d3e4f5","name":"Ann Smith","dateOfBirth":"1980-01-01","countryOfBirth":"GB","ownershipPercentage":60}]`
+	var put []byte
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case r.URL.Path == "/v1/profiles/1/directors" && r.Method == http.MethodPost:
+			if !strings.Contains(string(body), `"dateOfBirth":"1977-12-18"`) {
+				t.Errorf("expected date of birth as YYYY-MM-DD, but got %s", body)
+			}
+			w.Write([]byte(`[{"id":10,"firstName":"John","lastName":"Doe","dateOfBirth":"1977-12-18","countryOfResidence":"GB"}]`))
+		case r.URL.Path == "/v1/profiles/1/directors" && r.Method == http.MethodGet:
+			w.Write([]byte(`[{"id":10,"firstName":"John"},{"id":11,"firstName":"Jane"}]`))
+		case r.URL.Path == "/v1/profiles/1/directors" && r.Method == http.MethodPut:
+			put = body
+			w.Write(body)
+		case r.URL.Path == "/v1/profiles/1/ubos" && r.Method == http.MethodGet:
+			w.Write([]byte(ubos))
+		case r.URL.Path == "/v1/profiles/1/ubos" && r.Method == http.MethodPost:
+			w.Write(body)
+		case r.URL.Path == "/v1/profiles/1/shareholders" && r.Method == http.MethodPut:
+			w.Write(body)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	dob := TwDate{time.Date(1977, 12, 18, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("directors", func(t *testing.T) {
+		d, err := api.AddDirectors(1, []BusinessDirector{{FirstName: "John", LastName: "Doe", DateOfBirth: dob, CountryOfResidence: "GB"}})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(d) != 1 || d[0].ID != 10 || d[0].DateOfBirth.Year() != 1977 {
+			t.Errorf("unexpected directors: %#v", d)
+		}
+	})
+
+	t.Run("removeDirector", func(t *testing.T) {
+		if err := api.RemoveDirector(1, 10); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		d := []BusinessDirector{}
+		json.Unmarshal(put, &d)
+		if len(d) != 1 || d[0].ID != 11 {
+			t.Errorf("expected only director 11 to remain, but got %s", put)
+		}
+
+		if err := api.RemoveDirector(1, 99); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected not found, but got %v", err)
+		}
+	})
+
+	t.Run("ubos", func(t *testing.T) {
+		if _, err := api.AddUBOs(1, []UBO{{Name: "Bob", DateOfBirth: dob, OwnershipPercentage: 50}}); err == nil {
+			t.Errorf("expected an error when ownership exceeds 100%%")
+		}
+
+		u, err := api.AddUBOs(1, []UBO{{Name: "Bob", DateOfBirth: dob, OwnershipPercentage: 40}})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(u) != 1 || u[0].OwnershipPercentage != 40 {
+			t.Errorf("unexpected ubos: %#v", u)
+		}
+	})
+
+	t.Run("shareholders", func(t *testing.T) {
+		s := []Shareholder{
+			{Type: PersonShareholder, FirstName: "Ann", OwnershipPercentage: 50},
+			{Type: BusinessShareholder, BusinessName: "Holding Ltd", OwnershipPercentage: 50},
+		}
+
+		if _, err := api.UpdateShareholders(1, s); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		split := []Shareholder{
+			{Type: PersonShareholder, FirstName: "Ann", OwnershipPercentage: 25.1},
+			{Type: PersonShareholder, FirstName: "Bob", OwnershipPercentage: 25.1},
+			{Type: PersonShareholder, FirstName: "Cid", OwnershipPercentage: 25.1},
+			{Type: BusinessShareholder, BusinessName: "Holding Ltd", OwnershipPercentage: 24.7},
+		}
+		if _, err := api.UpdateShareholders(1, split); err != nil {
+			t.Errorf("expected a split adding up to 100%% to pass, but got %v", err)
+		}
+
+		s[0].OwnershipPercentage = 0
+		if _, err := api.UpdateShareholders(1, s); err == nil {
+			t.Errorf("expected an error for a zero percentage")
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package transferwise
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -118,33 +119,38 @@ func (p profile) GetBusiness() *Business {
 	return &o
 }
 
+// QuoteRequest builds a quote bound to the profile, which can be used to create
+// a transfer. Use EstimateRequest for unauthenticated price estimates.
 func (p profile) QuoteRequest(source, target string, targetAmount, sourceAmount float64, t QuoteRequestType) (quoteRequest, error) {
-	if (targetAmount <= None && sourceAmount <= None) || (targetAmount > None && sourceAmount > None) {
-		return quoteRequest{}, fmt.Errorf("specify either a target or source amount ")
-	}
-
-	q := quoteRequest{
-		Profile:  p.ID,
-		Source:   source,
-		Target:   target,
-		RateType: "FIXED",
-		Type:     t,
-	}
+	return p.QuoteRequestDecimal(source, target, DecimalFromFloat(targetAmount), DecimalFromFloat(sourceAmount), t)
+}
 
-	if targetAmount > None {
-		q.TargetAmount = targetAmount
+func (p profile) QuoteRequestDecimal(source, target string, targetAmount, sourceAmount Decimal, t QuoteRequestType) (quoteRequest, error) {
+	s, tc, ta, sa, err := quoteAmounts(source, target, targetAmount, sourceAmount)
+	if err != nil {
+		return quoteRequest{}, err
 	}
 
-	if sourceAmount > None {
-		q.SourceAmount = sourceAmount
+	q := quoteRequest{
+		Profile:      p.ID,
+		Source:       string(s),
+		Target:       string(tc),
+		RateType:     "FIXED",
+		TargetAmount: ta,
+		SourceAmount: sa,
+		Type:         t,
 	}
 
 	return q, nil
 }
 
 func (a *API) Profiles() ([]profile, error) {
+	return a.ProfilesContext(context.Background())
+}
+
+func (a *API) ProfilesContext(ctx context.Context) ([]profile, error) {
 	res := []profile{}
-	if err := a.do("v1/profiles", http.MethodGet, nil, &res); err != nil {
+	if err := a.do(ctx, "v1/profiles", http.MethodGet, nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -180,7 +186,11 @@ type BusinessProfileRequest struct {
 	Webpage            string      `json:"webpage"`
 }
 
-func (a *API) CreateProfile(r interface{}) (*profile, error) {
+func (a *API) CreateProfile(r interface{}, options ...ReqOption) (*profile, error) {
+	return a.CreateProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) CreateProfileContext(ctx context.Context, r interface{}, options ...ReqOption) (*profile, error) {
 	p := profile{}
 
 	reqOk := false
@@ -191,7 +201,7 @@ func (a *API) CreateProfile(r interface{}) (*profile, error) {
 			Details: pr,
 		}
 
-		if err := a.do("v1/profiles", http.MethodPost, &req, &p); err != nil {
+		if err := a.do(ctx, "v1/profiles", http.MethodPost, &req, &p, options...); err != nil {
 			return nil, err
 		}
 	}
@@ -203,7 +213,7 @@ func (a *API) CreateProfile(r interface{}) (*profile, error) {
 			Details: br,
 		}
 
-		if err := a.do("v1/profiles", http.MethodPost, &req, &p); err != nil {
+		if err := a.do(ctx, "v1/profiles", http.MethodPost, &req, &p, options...); err != nil {
 			return nil, err
 		}
 	}
@@ -215,8 +225,12 @@ func (a *API) CreateProfile(r interface{}) (*profile, error) {
 	return &p, nil
 }
 
-func (a *API) CreatePersonalProfile(r PersonalProfileRequest) (*Person, error) {
-	p, err := a.CreateProfile(r)
+func (a *API) CreatePersonalProfile(r PersonalProfileRequest, options ...ReqOption) (*Person, error) {
+	return a.CreatePersonalProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) CreatePersonalProfileContext(ctx context.Context, r PersonalProfileRequest, options ...ReqOption) (*Person, error) {
+	p, err := a.CreateProfileContext(ctx, r, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -228,8 +242,12 @@ func (a *API) CreatePersonalProfile(r PersonalProfileRequest) (*Person, error) {
 	return p.GetPerson(), nil
 }
 
-func (a *API) CreateBusinessProfile(r BusinessProfileRequest) (*Business, error) {
-	b, err := a.CreateProfile(r)
+func (a *API) CreateBusinessProfile(r BusinessProfileRequest, options ...ReqOption) (*Business, error) {
+	return a.CreateBusinessProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) CreateBusinessProfileContext(ctx context.Context, r BusinessProfileRequest, options ...ReqOption) (*Business, error) {
+	b, err := a.CreateProfileContext(ctx, r, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +259,11 @@ func (a *API) CreateBusinessProfile(r BusinessProfileRequest) (*Business, error)
 	return b.GetBusiness(), nil
 }
 
-func (a *API) UpdateProfile(r interface{}) (*profile, error) {
+func (a *API) UpdateProfile(r interface{}, options ...ReqOption) (*profile, error) {
+	return a.UpdateProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) UpdateProfileContext(ctx context.Context, r interface{}, options ...ReqOption) (*profile, error) {
 	p := profile{}
 
 	reqOk := false
@@ -252,7 +274,7 @@ func (a *API) UpdateProfile(r interface{}) (*profile, error) {
 			Details: pr,
 		}
 
-		if err := a.do("v1/profiles", http.MethodPut, &req, &p); err != nil {
+		if err := a.do(ctx, "v1/profiles", http.MethodPut, &req, &p, options...); err != nil {
 			return nil, err
 		}
 	}
@@ -264,7 +286,7 @@ func (a *API) UpdateProfile(r interface{}) (*profile, error) {
 			Details: br,
 		}
 
-		if err := a.do("v1/profiles", http.MethodPut, &req, &p); err != nil {
+		if err := a.do(ctx, "v1/profiles", http.MethodPut, &req, &p, options...); err != nil {
 			return nil, err
 		}
 	}
@@ -276,8 +298,12 @@ func (a *API) UpdateProfile(r interface{}) (*profile, error) {
 	return &p, nil
 }
 
-func (a *API) UpdatePersonalProfile(r PersonalProfileRequest) (*Person, error) {
-	p, err := a.UpdateProfile(r)
+func (a *API) UpdatePersonalProfile(r PersonalProfileRequest, options ...ReqOption) (*Person, error) {
+	return a.UpdatePersonalProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) UpdatePersonalProfileContext(ctx context.Context, r PersonalProfileRequest, options ...ReqOption) (*Person, error) {
+	p, err := a.UpdateProfileContext(ctx, r, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -289,8 +315,12 @@ func (a *API) UpdatePersonalProfile(r PersonalProfileRequest) (*Person, error) {
 	return p.GetPerson(), nil
 }
 
-func (a *API) UpdateBusinessProfile(r BusinessProfileRequest) (*Business, error) {
-	b, err := a.UpdateProfile(r)
+func (a *API) UpdateBusinessProfile(r BusinessProfileRequest, options ...ReqOption) (*Business, error) {
+	return a.UpdateBusinessProfileContext(context.Background(), r, options...)
+}
+
+func (a *API) UpdateBusinessProfileContext(ctx context.Context, r BusinessProfileRequest, options ...ReqOption) (*Business, error) {
+	b, err := a.UpdateProfileContext(ctx, r, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -303,8 +333,12 @@ func (a *API) UpdateBusinessProfile(r BusinessProfileRequest) (*Business, error)
 }
 
 func (a *API) GetProfile(id int) (*profile, error) {
+	return a.GetProfileContext(context.Background(), id)
+}
+
+func (a *API) GetProfileContext(ctx context.Context, id int) (*profile, error) {
 	p := profile{}
-	if err := a.do("v1/profiles"+strconv.Itoa(id), http.MethodGet, nil, &p); err != nil {
+	if err := a.do(ctx, "v1/profiles/"+strconv.Itoa(id), http.MethodGet, nil, &p); err != nil {
 		return nil, err
 	}
 
@@ -312,7 +346,11 @@ func (a *API) GetProfile(id int) (*profile, error) {
 }
 
 func (a *API) GetPerson(id int) (*Person, error) {
-	p, err := a.GetProfile(id)
+	return a.GetPersonContext(context.Background(), id)
+}
+
+func (a *API) GetPersonContext(ctx context.Context, id int) (*Person, error) {
+	p, err := a.GetProfileContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +363,11 @@ func (a *API) GetPerson(id int) (*Person, error) {
 }
 
 func (a *API) GetBusiness(id int) (*Business, error) {
-	b, err := a.GetProfile(id)
+	return a.GetBusinessContext(context.Background(), id)
+}
+
+func (a *API) GetBusinessContext(ctx context.Context, id int) (*Business, error) {
+	b, err := a.GetProfileContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -341,11 +383,11 @@ type DocumentType string
 
 var (
 	DriversLicence DocumentType = "DRIVERS_LICENSE"
-	IdentityCard                = "IDENTITY_CARD"
-	GreenCard                   = "GREEN_CARD"
-	MyNumber                    = "MY_NUMBER"
-	Passport                    = "PASSPORT"
-	Other                       = "OTHER"
+	IdentityCard   DocumentType = "IDENTITY_CARD"
+	GreenCard      DocumentType = "GREEN_CARD"
+	MyNumber       DocumentType = "MY_NUMBER"
+	Passport       DocumentType = "PASSPORT"
+	Other          DocumentType = "OTHER"
 )
 
 type verificationDocument struct {
@@ -361,7 +403,11 @@ type verificationDocument struct {
 
 var NoExpiry time.Time
 
-func (a *API) VerificationDocument(p *Person, t DocumentType, id string, issued time.Time, country string, state string, expires time.Time) error {
+func (a *API) VerificationDocument(p *Person, t DocumentType, id string, issued time.Time, country string, state string, expires time.Time, options ...ReqOption) error {
+	return a.VerificationDocumentContext(context.Background(), p, t, id, issued, country, state, expires, options...)
+}
+
+func (a *API) VerificationDocumentContext(ctx context.Context, p *Person, t DocumentType, id string, issued time.Time, country string, state string, expires time.Time, options ...ReqOption) error {
 	d := verificationDocument{
 		FirstName:        p.Details.FirstName,
 		LastName:         p.Details.LastName,
@@ -377,7 +423,7 @@ func (a *API) VerificationDocument(p *Person, t DocumentType, id string, issued
 	}
 
 	url := fmt.Sprintf("v1/profiles/%d/verification-documents", p.ID)
-	if err := a.do(url, http.MethodPost, d, nil); err != nil {
+	if err := a.do(ctx, url, http.MethodPost, d, nil, options...); err != nil {
 		return err
 	}
 
@@ -1,6 +1,7 @@
 package transferwise
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -18,11 +19,12 @@ type quoteRequest struct {
 	Source       string           `json:"source"`
 	Target       string           `json:"target"`
 	RateType     string           `json:"rateType"`
-	TargetAmount float64          `json:"targetAmount,omitempty"`
-	SourceAmount float64          `json:"sourceAmount,omitempty"`
+	TargetAmount *Decimal         `json:"targetAmount,omitempty"`
+	SourceAmount *Decimal         `json:"sourceAmount,omitempty"`
 	Type         QuoteRequestType `json:"type"`
 }
 
+// None marks an absent amount in the float64 quote builders.
 var None float64 = 0.0
 
 type QuoteResponse struct {
@@ -31,31 +33,56 @@ type QuoteResponse struct {
 	Source                 string           `json:"source"`
 	Target                 string           `json:"target"`
 	RateType               string           `json:"rateType"`
-	TargetAmount           float64          `json:"targetAmount,omitempty"`
-	SourceAmount           float64          `json:"sourceAmount,omitempty"`
+	TargetAmount           Decimal          `json:"targetAmount"`
+	SourceAmount           Decimal          `json:"sourceAmount"`
 	Type                   QuoteRequestType `json:"type"`
-	Rate                   float64          `json:"rate"`
+	Rate                   Decimal          `json:"rate"`
 	Created                time.Time        `json:"createdTime"`
 	UserID                 int              `json:"createdByUserId"`
+	Expiration             time.Time        `json:"expirationTime"`
 	DeliveryEstimate       time.Time        `json:"deliveryEstimate"`
-	Fee                    float64          `json:"fee"`
+	Fee                    Decimal          `json:"fee"`
 	AllowedProfileTypes    []string         `json:"allowedProfileTypes"`
 	GuaranteedTargetAmount bool             `json:"guaranteedTargetAmount,omitempty"`
 	OfSourceAmount         bool             `json:"ofSourceAmount,omitempty"`
 }
 
-func (a *API) Quote(r quoteRequest) (*QuoteResponse, error) {
+func (q QuoteResponse) SourceMoney() Amount {
+	return NewAmount(q.SourceAmount, q.Source)
+}
+
+func (q QuoteResponse) TargetMoney() Amount {
+	return NewAmount(q.TargetAmount, q.Target)
+}
+
+func (q QuoteResponse) FeeMoney() Amount {
+	return NewAmount(q.Fee, q.Source)
+}
+
+func (a *API) Quote(r quoteRequest, options ...ReqOption) (*QuoteResponse, error) {
+	return a.QuoteContext(context.Background(), r, options...)
+}
+
+func (a *API) QuoteContext(ctx context.Context, r quoteRequest, options ...ReqOption) (*QuoteResponse, error) {
+	if err := a.checkPair(r.Source, r.Target); err != nil {
+		return nil, err
+	}
+
 	d := QuoteResponse{}
-	if err := a.do("v1/quotes", http.MethodPost, r, &d); err != nil {
+	if err := a.do(ctx, "v1/quotes", http.MethodPost, r, &d, options...); err != nil {
 		return nil, err
 	}
 	return &d, nil
 }
 
 func (a *API) QuoteByID(id int) (*QuoteResponse, error) {
+	return a.QuoteByIDContext(context.Background(), id)
+}
+
+func (a *API) QuoteByIDContext(ctx context.Context, id int) (*QuoteResponse, error) {
 	d := QuoteResponse{}
 	url := fmt.Sprintf("v1/quotes/%d", id)
-	if err := a.do(url, http.MethodGet, nil, &d); err != nil {
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
 		return nil, err
 	}
 	return &d, nil
@@ -69,45 +96,37 @@ type PayInMethods struct {
 }
 
 func (a *API) PayInMethods(id int) (*PayInMethods, error) {
+	return a.PayInMethodsContext(context.Background(), id)
+}
+
+func (a *API) PayInMethodsContext(ctx context.Context, id int) (*PayInMethods, error) {
 	d := PayInMethods{}
 	url := fmt.Sprintf("v1/quotes/%d/pay-in-methods", id)
-	if err := a.do(url, http.MethodGet, nil, &d); err != nil {
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
 		return nil, err
 	}
 	return &d, nil
 }
 
-type temporaryQuoteRequest struct {
-	Source       string  `json:"source"`
-	Target       string  `json:"target"`
-	RateType     string  `json:"rateType"`
-	TargetAmount float64 `json:"targetAmount,omitempty"`
-	SourceAmount float64 `json:"sourceAmount,omitempty"`
-}
-
-func (a *API) TemoraryQuote(source, target string, targetAmount, sourceAmount float64) (*QuoteResponse, error) {
-	if (targetAmount <= None && sourceAmount <= None) || (targetAmount > None && sourceAmount > None) {
-		return nil, fmt.Errorf("specify either a target or source amount ")
+// quoteAmounts validates the currency pair and that exactly one of the amounts
+// is set, returning the normalized currencies and the amount to send.
+func quoteAmounts(source, target string, targetAmount, sourceAmount Decimal) (Currency, Currency, *Decimal, *Decimal, error) {
+	if (targetAmount.Sign() <= 0) == (sourceAmount.Sign() <= 0) {
+		return "", "", nil, nil, fmt.Errorf("%w: specify either a target or source amount", ErrValidation)
 	}
 
-	req := temporaryQuoteRequest{
-		Source:   source,
-		Target:   target,
-		RateType: "FIXED",
+	s, err := ParseCurrency(source)
+	if err != nil {
+		return "", "", nil, nil, err
 	}
 
-	if targetAmount > None {
-		req.TargetAmount = targetAmount
+	t, err := ParseCurrency(target)
+	if err != nil {
+		return "", "", nil, nil, err
 	}
 
-	if sourceAmount > None {
-		req.SourceAmount = sourceAmount
+	if targetAmount.Sign() > 0 {
+		return s, t, &targetAmount, nil, nil
 	}
-
-	r := QuoteResponse{}
-	if err := a.do("v1/quotes", http.MethodGet, req, &r); err != nil {
-		return nil, err
-	}
-
-	return &r, nil
+	return s, t, nil, &sourceAmount, nil
 }
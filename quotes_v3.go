@@ -0,0 +1,255 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type PaymentMethod string
+
+var (
+	BankTransfer   PaymentMethod = "BANK_TRANSFER"
+	BalancePayment PaymentMethod = "BALANCE"
+	DebitCard      PaymentMethod = "DEBIT"
+	CreditCard     PaymentMethod = "CREDIT"
+	SwiftPayment   PaymentMethod = "SWIFT"
+	InterACPayment PaymentMethod = "INTERAC"
+)
+
+type QuoteFee struct {
+	TransferWise Decimal `json:"transferwise"`
+	PayIn        Decimal `json:"payIn"`
+	Discount     Decimal `json:"discount"`
+	Partner      Decimal `json:"partner"`
+	Total        Decimal `json:"total"`
+}
+
+type PriceItem struct {
+	ID    int    `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	Value struct {
+		Amount   Decimal `json:"amount"`
+		Currency string  `json:"currency"`
+		Label    string  `json:"label"`
+	} `json:"value"`
+}
+
+type QuotePrice struct {
+	PriceSetID int         `json:"priceSetId"`
+	Total      PriceItem   `json:"total"`
+	Items      []PriceItem `json:"items"`
+}
+
+type QuoteNotice struct {
+	Text string `json:"text"`
+	Link string `json:"link"`
+	Type string `json:"type"`
+}
+
+type PaymentOption struct {
+	Disabled       bool `json:"disabled"`
+	DisabledReason *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"disabledReason"`
+	EstimatedDelivery          time.Time     `json:"estimatedDelivery"`
+	FormattedEstimatedDelivery string        `json:"formattedEstimatedDelivery"`
+	Fee                        QuoteFee      `json:"fee"`
+	Price                      QuotePrice    `json:"price"`
+	SourceAmount               Decimal       `json:"sourceAmount"`
+	TargetAmount               Decimal       `json:"targetAmount"`
+	SourceCurrency             string        `json:"sourceCurrency"`
+	TargetCurrency             string        `json:"targetCurrency"`
+	PayIn                      PaymentMethod `json:"payIn"`
+	PayOut                     PaymentMethod `json:"payOut"`
+	AllowedProfileTypes        []string      `json:"allowedProfileTypes"`
+	PayInProduct               string        `json:"payInProduct"`
+	FeePercentage              Decimal       `json:"feePercentage"`
+}
+
+func (o PaymentOption) TotalFee() Amount {
+	return NewAmount(o.Fee.Total, o.SourceCurrency)
+}
+
+type QuoteV3 struct {
+	ID                            string           `json:"id"`
+	SourceCurrency                string           `json:"sourceCurrency"`
+	TargetCurrency                string           `json:"targetCurrency"`
+	SourceAmount                  Decimal          `json:"sourceAmount"`
+	TargetAmount                  Decimal          `json:"targetAmount"`
+	PayOut                        PaymentMethod    `json:"payOut"`
+	PreferredPayIn                PaymentMethod    `json:"preferredPayIn"`
+	Rate                          Decimal          `json:"rate"`
+	Created                       time.Time        `json:"createdTime"`
+	User                          int              `json:"user"`
+	Profile                       int              `json:"profile"`
+	TargetAccount                 int              `json:"targetAccount"`
+	RateType                      string           `json:"rateType"`
+	RateExpiration                time.Time        `json:"rateExpirationTime"`
+	GuaranteedTargetAmountAllowed bool             `json:"guaranteedTargetAmountAllowed"`
+	TargetAmountAllowed           bool             `json:"targetAmountAllowed"`
+	GuaranteedTargetAmount        bool             `json:"guaranteedTargetAmount"`
+	ProvidedAmountType            string           `json:"providedAmountType"`
+	PaymentOptions                []PaymentOption  `json:"paymentOptions"`
+	Status                        string           `json:"status"`
+	Expiration                    time.Time        `json:"expirationTime"`
+	Notices                       []QuoteNotice    `json:"notices"`
+	Type                          QuoteRequestType `json:"type,omitempty"`
+}
+
+func enabledOptions(options []PaymentOption, payOut PaymentMethod) []PaymentOption {
+	var res []PaymentOption
+	for _, o := range options {
+		if o.Disabled || (payOut != "" && o.PayOut != payOut) {
+			continue
+		}
+		res = append(res, o)
+	}
+	return res
+}
+
+func cheaper(a, b PaymentOption) bool {
+	if c := a.Fee.Total.Cmp(b.Fee.Total); c != 0 {
+		return c < 0
+	}
+	return a.EstimatedDelivery.Before(b.EstimatedDelivery)
+}
+
+func faster(a, b PaymentOption) bool {
+	if !a.EstimatedDelivery.Equal(b.EstimatedDelivery) {
+		return a.EstimatedDelivery.Before(b.EstimatedDelivery)
+	}
+	return a.Fee.Total.Cmp(b.Fee.Total) < 0
+}
+
+func pickOption(options []PaymentOption, less func(a, b PaymentOption) bool) (*PaymentOption, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: no payment options available", ErrNotFound)
+	}
+
+	sort.SliceStable(options, func(i, j int) bool { return less(options[i], options[j]) })
+	return &options[0], nil
+}
+
+func findOption(options []PaymentOption, payIn PaymentMethod) (*PaymentOption, error) {
+	for _, o := range options {
+		if o.PayIn == payIn {
+			return &o, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no %s payment option available", ErrNotFound, payIn)
+}
+
+// Options returns the enabled payment options for the quote's pay-out method,
+// or all enabled options when the quote has no pay-out method.
+func (q QuoteV3) Options() []PaymentOption {
+	return enabledOptions(q.PaymentOptions, q.PayOut)
+}
+
+// Cheapest returns the payment option with the lowest total fee, preferring the
+// earliest delivery between options with equal fees.
+func (q QuoteV3) Cheapest() (*PaymentOption, error) {
+	return pickOption(q.Options(), cheaper)
+}
+
+// Fastest returns the payment option with the earliest estimated delivery,
+// preferring the lowest fee between options with equal delivery.
+func (q QuoteV3) Fastest() (*PaymentOption, error) {
+	return pickOption(q.Options(), faster)
+}
+
+func (q QuoteV3) Option(payIn PaymentMethod) (*PaymentOption, error) {
+	return findOption(q.Options(), payIn)
+}
+
+type quoteV3Request struct {
+	Profile        int           `json:"-"`
+	SourceCurrency string        `json:"sourceCurrency"`
+	TargetCurrency string        `json:"targetCurrency"`
+	SourceAmount   *Decimal      `json:"sourceAmount,omitempty"`
+	TargetAmount   *Decimal      `json:"targetAmount,omitempty"`
+	TargetAccount  int           `json:"targetAccount,omitempty"`
+	PayOut         PaymentMethod `json:"payOut,omitempty"`
+	PreferredPayIn PaymentMethod `json:"preferredPayIn,omitempty"`
+}
+
+func (p profile) QuoteV3Request(source, target string, targetAmount, sourceAmount Decimal) (quoteV3Request, error) {
+	s, t, ta, sa, err := quoteAmounts(source, target, targetAmount, sourceAmount)
+	if err != nil {
+		return quoteV3Request{}, err
+	}
+
+	return quoteV3Request{
+		Profile:        p.ID,
+		SourceCurrency: string(s),
+		TargetCurrency: string(t),
+		SourceAmount:   sa,
+		TargetAmount:   ta,
+	}, nil
+}
+
+func (a *API) CreateQuoteV3(r quoteV3Request, options ...ReqOption) (*QuoteV3, error) {
+	return a.CreateQuoteV3Context(context.Background(), r, options...)
+}
+
+func (a *API) CreateQuoteV3Context(ctx context.Context, r quoteV3Request, options ...ReqOption) (*QuoteV3, error) {
+	if err := a.checkPair(r.SourceCurrency, r.TargetCurrency); err != nil {
+		return nil, err
+	}
+
+	d := QuoteV3{}
+	url := fmt.Sprintf("v3/profiles/%d/quotes", r.Profile)
+	if err := a.do(ctx, url, http.MethodPost, r, &d, options...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) QuoteV3ByID(profileID int, id string) (*QuoteV3, error) {
+	return a.QuoteV3ByIDContext(context.Background(), profileID, id)
+}
+
+func (a *API) QuoteV3ByIDContext(ctx context.Context, profileID int, id string) (*QuoteV3, error) {
+	d := QuoteV3{}
+	url := fmt.Sprintf("v3/profiles/%d/quotes/%s", profileID, id)
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+type quoteUpdateRequest struct {
+	TargetAccount int           `json:"targetAccount"`
+	PayOut        PaymentMethod `json:"payOut,omitempty"`
+}
+
+func withContentType(ct string) ReqOption {
+	return func(r *http.Request) error {
+		r.Header.Set("Content-Type", ct)
+		return nil
+	}
+}
+
+func (a *API) UpdateQuoteV3(profileID int, id string, targetAccount int, payOut PaymentMethod, options ...ReqOption) (*QuoteV3, error) {
+	return a.UpdateQuoteV3Context(context.Background(), profileID, id, targetAccount, payOut, options...)
+}
+
+// UpdateQuoteV3Context sets the recipient of a quote, so that its pricing and
+// payment options reflect the recipient's payout method.
+func (a *API) UpdateQuoteV3Context(ctx context.Context, profileID int, id string, targetAccount int, payOut PaymentMethod, options ...ReqOption) (*QuoteV3, error) {
+	if targetAccount == 0 {
+		return nil, fmt.Errorf("target account is required")
+	}
+
+	d := QuoteV3{}
+	url := fmt.Sprintf("v3/profiles/%d/quotes/%s", profileID, id)
+	req := quoteUpdateRequest{TargetAccount: targetAccount, PayOut: payOut}
+	if err := a.do(ctx, url, http.MethodPatch, req, &d, append([]ReqOption{withContentType("application/merge-patch+json")}, options...)...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,142 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestQuotesV3(t *testing.T) {
+	const quote = `{"id":"11144c35-9fe8-4c32-b7fd-d05c2a7734bf","sourceCurrency":"GBP","targetCurrency":"USD","sourceAmount":100,"payOut":"BANK_TRANSFER","preferredPayIn":"BANK_TRANSFER","rate":1.30445,"createdTime":"2019-04-05T13:18:58Z","user":55,"profile":101,"rateType":"FIXED","rateExpirationTime":"2019-04-08T13:18:57Z","providedAmountType":"SOURCE","status":"PENDING","expirationTime":"2019-04-05T13:48:58Z","notices":[{"text":"You can have a maximum of 3 open transfers","link":"","type":"WARNING"}],
+	"paymentOptions":[
+		{"disabled":false,"estimatedDelivery":"2019-04-08T12:30:00Z","fee":{"transferwise":3.04,"payIn":0,"discount":2.27,"partner":0,"total":0.77},"price":{"priceSetId":238,"total":{"type":"TOTAL","label":"Total fees","value":{"amount":0.77,"currency":"GBP","label":"0.77 GBP"}},"items":[{"type":"FEE","label":"fee","value":{"amount":0,"currency":"GBP"}}]},"sourceAmount":100,"targetAmount":129.24,"sourceCurrency":"GBP","targetCurrency":"USD","payIn":"BANK_TRANSFER","payOut":"BANK_TRANSFER"},
+		{"disabled":false,"estimatedDelivery":"2019-04-05T14:30:00Z","fee":{"transferwise":3.04,"payIn":1.25,"discount":2.27,"partner":0,"total":2.02},"sourceAmount":100,"targetAmount":127.61,"sourceCurrency":"GBP","targetCurrency":"USD","payIn":"DEBIT","payOut":"BANK_TRANSFER"},
+		{"disabled":false,"estimatedDelivery":"2019-04-05T13:30:00Z","fee":{"total":0.5},"sourceAmount":100,"targetAmount":129.5,"sourceCurrency":"GBP","targetCurrency":"USD","payIn":"BALANCE","payOut":"SWIFT"},
+		{"disabled":true,"disabledReason":{"code":"error.payInmethod.disabled","message":"Not available"},"estimatedDelivery":"2019-04-05T13:00:00Z","fee":{"total":0.1},"sourceCurrency":"GBP","targetCurrency":"USD","payIn":"CREDIT","payOut":"BANK_TRANSFER"}
+	]}`
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/profiles/101/quotes":
+			req := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if req["sourceCurrency"] != "GBP" || req["targetCurrency"] != "USD" || req["sourceAmount"] != 100.0 || req["targetAmount"] != nil {
+				t.Errorf("unexpected request: %v", req)
+			}
+
+			w.Write([]byte(quote))
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/profiles/101/quotes/11144c35-9fe8-4c32-b7fd-d05c2a7734bf":
+			w.Write([]byte(quote))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v3/profiles/101/quotes/11144c35-9fe8-4c32-b7fd-d05c2a7734bf":
+			if ct := r.Header.Get("Content-Type"); ct != "application/merge-patch+json" {
+				t.Errorf("expected merge patch content type, but got %v", ct)
+			}
+
+			req := quoteUpdateRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if req.TargetAccount != 13 || req.PayOut != SwiftPayment {
+				t.Errorf("unexpected request: %#v", req)
+			}
+
+			w.Write([]byte(`{"id":"11144c35-9fe8-4c32-b7fd-d05c2a7734bf","targetAccount":13,"payOut":"SWIFT","paymentOptions":[]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	p := profile{ID: 101}
+
+	t.Run("create", func(t *testing.T) {
+		req, err := p.QuoteV3Request("GBP", "USD", Decimal{}, NewDecimal(100, 0))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		q, err := api.CreateQuoteV3(req)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if q.ID != "11144c35-9fe8-4c32-b7fd-d05c2a7734bf" || q.Rate.String() != "1.30445" || len(q.PaymentOptions) != 4 || len(q.Notices) != 1 {
+			t.Errorf("unexpected quote: %#v", q)
+		}
+
+		f := q.PaymentOptions[0].Fee
+		if f.TransferWise.String() != "3.04" || f.Discount.String() != "2.27" || f.Total.String() != "0.77" || q.PaymentOptions[0].Price.PriceSetID != 238 {
+			t.Errorf("unexpected fee breakdown: %#v", q.PaymentOptions[0])
+		}
+
+		if _, err := p.QuoteV3Request("GBP", "USDD", Decimal{}, NewDecimal(100, 0)); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		q, err := api.QuoteV3ByID(101, "11144c35-9fe8-4c32-b7fd-d05c2a7734bf")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if o := q.Options(); len(o) != 2 {
+			t.Errorf("expected 2 bank transfer options, but got %d", len(o))
+		}
+
+		c, err := q.Cheapest()
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if c.PayIn != BankTransfer || c.TotalFee().String() != "0.77 GBP" {
+			t.Errorf("unexpected cheapest option: %#v", c)
+		}
+
+		f, err := q.Fastest()
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if f.PayIn != DebitCard {
+			t.Errorf("expected the debit option to be fastest, but got %v", f.PayIn)
+		}
+
+		if _, err := q.Option(CreditCard); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected disabled options to be skipped, but got %v", err)
+		}
+
+		tr, err := q.TransferRequest(13, "", TransferDetails{})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if tr.QuoteUUID != q.ID || tr.Quote != 0 {
+			t.Errorf("unexpected transfer request: %#v", tr)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		q, err := api.UpdateQuoteV3(101, "11144c35-9fe8-4c32-b7fd-d05c2a7734bf", 13, SwiftPayment)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if q.TargetAccount != 13 || q.PayOut != SwiftPayment {
+			t.Errorf("unexpected quote: %#v", q)
+		}
+
+		if _, err := q.Cheapest(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected no options, but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EndpointGroup string
+
+var (
+	AllEndpoints      EndpointGroup = "all"
+	QuoteEndpoints    EndpointGroup = "quotes"
+	TransferEndpoints EndpointGroup = "transfers"
+	ReadEndpoints     EndpointGroup = "reads"
+	WriteEndpoints    EndpointGroup = "writes"
+)
+
+func endpointGroup(url, method string) EndpointGroup {
+	path := url
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	switch {
+	case strings.Contains(path, "quotes"):
+		return QuoteEndpoints
+	case strings.Contains(path, "transfers"):
+		return TransferEndpoints
+	case method == http.MethodGet || method == http.MethodHead:
+		return ReadEndpoints
+	default:
+		return WriteEndpoints
+	}
+}
+
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l RateLimit) *bucket {
+	return &bucket{
+		rate:   l.Rate,
+		burst:  float64(l.Burst),
+		tokens: float64(l.Burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *bucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	d := b.reserve()
+	if d == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func WithRateLimit(g EndpointGroup, l RateLimit) APIOption {
+	return func(a *API) error {
+		if l.Rate <= 0 || l.Burst < 1 {
+			return fmt.Errorf("rate limit should have a positive rate and a burst of at least 1")
+		}
+
+		if a.limits == nil {
+			a.limits = map[EndpointGroup]*bucket{}
+		}
+
+		a.limits[g] = newBucket(l)
+		return nil
+	}
+}
+
+func (a *API) throttle(ctx context.Context, url, method string) error {
+	if len(a.limits) == 0 {
+		return nil
+	}
+
+	for _, g := range []EndpointGroup{AllEndpoints, endpointGroup(url, method)} {
+		b, ok := a.limits[g]
+		if !ok {
+			continue
+		}
+
+		if err := b.wait(ctx); err != nil {
+			return fmt.Errorf("rate limit error: %w", err)
+		}
+	}
+
+	return nil
+}
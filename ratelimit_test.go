@@ -0,0 +1,78 @@
+package transferwise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	t.Run("throttles", func(t *testing.T) {
+		api := newTestAPI(t, h, WithRateLimit(AllEndpoints, RateLimit{Rate: 20, Burst: 1}))
+
+		start := time.Now()
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := api.QuoteByID(1); err != nil {
+					t.Errorf("expected to pass, but got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if d := time.Since(start); d < 180*time.Millisecond {
+			t.Errorf("expected 5 calls at 20/s to take at least 200ms, but took %v", d)
+		}
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		api := newTestAPI(t, h, WithRateLimit(QuoteEndpoints, RateLimit{Rate: 0.001, Burst: 1}))
+
+		if _, err := api.QuoteByID(1); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if _, err := api.TransferByIDContext(ctx, 1); err != nil {
+			t.Errorf("expected transfers not to share the quote budget, but got %v", err)
+		}
+
+		if _, err := api.QuoteByIDContext(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, but got %v", err)
+		}
+	})
+
+	t.Run("endpointGroup", func(t *testing.T) {
+		for url, g := range map[string]EndpointGroup{
+			"v1/quotes/1":                        QuoteEndpoints,
+			"v3/profiles/1/transfers/2/payments": TransferEndpoints,
+			"v1/accounts?profile=1":              ReadEndpoints,
+		} {
+			if got := endpointGroup(url, http.MethodGet); got != g {
+				t.Errorf("%s: expected group %v, but got %v", url, g, got)
+			}
+		}
+
+		if got := endpointGroup("v1/accounts", http.MethodPost); got != WriteEndpoints {
+			t.Errorf("expected group %v, but got %v", WriteEndpoints, got)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := New("a", WithRateLimit(AllEndpoints, RateLimit{})); err == nil {
+			t.Errorf("expected an error for an empty rate limit")
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+)
+
+type RateGrouping string
+
+var (
+	GroupByDay    RateGrouping = "day"
+	GroupByHour   RateGrouping = "hour"
+	GroupByMinute RateGrouping = "minute"
+)
+
+const rateTimeFormat = "2006-01-02T15:04:05"
+
+type ExchangeRate struct {
+	Rate   Decimal `json:"rate"`
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Time   TwTime  `json:"time"`
+}
+
+func (r ExchangeRate) Convert(a Amount) (Amount, error) {
+	if a.Currency != r.Source {
+		return Amount{}, fmt.Errorf("currency mismatch: rate is for %s, but got %s", r.Source, a.Currency)
+	}
+	return NewAmount(a.Value.Mul(r.Rate), r.Target).Round(), nil
+}
+
+func rateQuery(source, target string) (urlpkg.Values, error) {
+	s, err := ParseCurrency(source)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := ParseCurrency(target)
+	if err != nil {
+		return nil, err
+	}
+
+	q := urlpkg.Values{}
+	q.Set("source", string(s))
+	q.Set("target", string(t))
+	return q, nil
+}
+
+func (a *API) rates(ctx context.Context, q urlpkg.Values) ([]ExchangeRate, error) {
+	res := []ExchangeRate{}
+	if err := a.do(ctx, "v1/rates?"+q.Encode(), http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) rate(ctx context.Context, q urlpkg.Values) (*ExchangeRate, error) {
+	res, err := a.rates(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: no rate for %s/%s", ErrNotFound, q.Get("source"), q.Get("target"))
+	}
+
+	return &res[0], nil
+}
+
+func (a *API) Rate(source, target string) (*ExchangeRate, error) {
+	return a.RateContext(context.Background(), source, target)
+}
+
+func (a *API) RateContext(ctx context.Context, source, target string) (*ExchangeRate, error) {
+	q, err := rateQuery(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.rate(ctx, q)
+}
+
+func (a *API) RateAt(source, target string, at time.Time) (*ExchangeRate, error) {
+	return a.RateAtContext(context.Background(), source, target, at)
+}
+
+func (a *API) RateAtContext(ctx context.Context, source, target string, at time.Time) (*ExchangeRate, error) {
+	if at.IsZero() {
+		return nil, fmt.Errorf("rate time is required")
+	}
+
+	q, err := rateQuery(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Set("time", at.UTC().Format(rateTimeFormat))
+	return a.rate(ctx, q)
+}
+
+func (a *API) RateHistory(source, target string, from, to time.Time, g RateGrouping) ([]ExchangeRate, error) {
+	return a.RateHistoryContext(context.Background(), source, target, from, to, g)
+}
+
+func (a *API) RateHistoryContext(ctx context.Context, source, target string, from, to time.Time, g RateGrouping) ([]ExchangeRate, error) {
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil, fmt.Errorf("invalid rate interval %v - %v", from, to)
+	}
+
+	switch g {
+	case GroupByDay, GroupByHour, GroupByMinute:
+	default:
+		return nil, fmt.Errorf("invalid rate grouping %q", g)
+	}
+
+	q, err := rateQuery(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Set("from", from.UTC().Format(rateTimeFormat))
+	q.Set("to", to.UTC().Format(rateTimeFormat))
+	q.Set("group", string(g))
+	return a.rates(ctx, q)
+}
@@ -0,0 +1,103 @@
+package transferwise
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRates(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/rates" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		q := r.URL.Query()
+		if q.Get("source") != "EUR" || q.Get("target") != "USD" {
+			t.Errorf("unexpected query: %v", r.URL.RawQuery)
+		}
+
+		switch {
+		case q.Get("group") != "":
+			if q.Get("from") != "2019-02-01T00:00:00" || q.Get("to") != "2019-02-03T00:00:00" || q.Get("group") != "day" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+
+			w.Write([]byte(`[{"rate":1.1455,"source":"EUR","target":"USD","time":"2019-02-01T00:00:00+0000"},{"rate":1.1461,"source":"EUR","target":"USD","time":"2019-02-02T00:00:00+0000"}]`))
+		case q.Get("time") != "":
+			if q.Get("time") != "2019-02-13T14:53:01" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+
+			w.Write([]byte(`[{"rate":1.1284,"source":"EUR","target":"USD","time":"2019-02-13T14:53:01+0000"}]`))
+		default:
+			w.Write([]byte(`[{"rate":1.166,"source":"EUR","target":"USD","time":"2018-08-31T10:43:31+0000"}]`))
+		}
+	}))
+
+	t.Run("current", func(t *testing.T) {
+		r, err := api.Rate("EUR", "USD")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if r.Rate.String() != "1.166" || !r.Time.Equal(time.Date(2018, 8, 31, 10, 43, 31, 0, time.UTC)) {
+			t.Errorf("unexpected rate: %#v", r)
+		}
+
+		a, err := r.Convert(NewAmount(MustParseDecimal("100"), "EUR"))
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if a.String() != "116.60 USD" {
+			t.Errorf("expected 116.60 USD, but got %v", a)
+		}
+
+		large := ExchangeRate{Rate: MustParseDecimal("1.16665"), Source: "EUR", Target: "USD"}
+		if a, err := large.Convert(NewAmount(MustParseDecimal("12345678901.12345678"), "EUR")); err != nil || a.String() != "14403086290.00 USD" {
+			t.Errorf("expected 14403086290.00 USD, but got %v and %v", a, err)
+		}
+	})
+
+	t.Run("at", func(t *testing.T) {
+		at := time.Date(2019, 2, 13, 15, 53, 1, 0, time.FixedZone("CET", 3600))
+		r, err := api.RateAt("EUR", "USD", at)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if r.Rate.String() != "1.1284" {
+			t.Errorf("unexpected rate: %#v", r)
+		}
+	})
+
+	t.Run("history", func(t *testing.T) {
+		from := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+		r, err := api.RateHistory("EUR", "USD", from, from.AddDate(0, 0, 2), GroupByDay)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(r) != 2 || r[1].Rate.String() != "1.1461" {
+			t.Errorf("unexpected rates: %#v", r)
+		}
+
+		if _, err := api.RateHistory("EUR", "USD", from, from, GroupByDay); err == nil {
+			t.Errorf("expected an error for an empty interval")
+		}
+
+		if _, err := api.RateHistory("EUR", "USD", from, from.AddDate(0, 0, 2), "week"); err == nil {
+			t.Errorf("expected an error for an invalid grouping")
+		}
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		if _, err := api.Rate("EURO", "USD"); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, but got %v", err)
+		}
+	})
+}
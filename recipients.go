@@ -0,0 +1,211 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	urlpkg "net/url"
+	"strconv"
+)
+
+type RecipientType string
+
+var (
+	IBANRecipient       RecipientType = "iban"
+	SortCodeRecipient   RecipientType = "sort_code"
+	ABARecipient        RecipientType = "aba"
+	AustralianRecipient RecipientType = "australian"
+	IndianRecipient     RecipientType = "indian"
+	EmailRecipient      RecipientType = "email"
+)
+
+type LegalType string
+
+var (
+	PrivateLegalType  LegalType = "PRIVATE"
+	BusinessLegalType LegalType = "BUSINESS"
+)
+
+type AccountType string
+
+var (
+	Checking AccountType = "CHECKING"
+	Savings  AccountType = "SAVINGS"
+)
+
+type RecipientAddress struct {
+	Country   string `json:"country,omitempty"`
+	State     string `json:"state,omitempty"`
+	City      string `json:"city,omitempty"`
+	PostCode  string `json:"postCode,omitempty"`
+	FirstLine string `json:"firstLine,omitempty"`
+}
+
+type RecipientDetails interface {
+	recipientType() RecipientType
+}
+
+type IBANDetails struct {
+	LegalType LegalType `json:"legalType"`
+	IBAN      string    `json:"IBAN"`
+	BIC       string    `json:"BIC,omitempty"`
+}
+
+func (IBANDetails) recipientType() RecipientType { return IBANRecipient }
+
+type SortCodeDetails struct {
+	LegalType     LegalType `json:"legalType"`
+	SortCode      string    `json:"sortCode"`
+	AccountNumber string    `json:"accountNumber"`
+}
+
+func (SortCodeDetails) recipientType() RecipientType { return SortCodeRecipient }
+
+type ABADetails struct {
+	LegalType     LegalType        `json:"legalType"`
+	ABARTN        string           `json:"abartn"`
+	AccountNumber string           `json:"accountNumber"`
+	AccountType   AccountType      `json:"accountType"`
+	Address       RecipientAddress `json:"address"`
+}
+
+func (ABADetails) recipientType() RecipientType { return ABARecipient }
+
+type AustralianDetails struct {
+	LegalType     LegalType `json:"legalType"`
+	BSBCode       string    `json:"bsbCode"`
+	AccountNumber string    `json:"accountNumber"`
+}
+
+func (AustralianDetails) recipientType() RecipientType { return AustralianRecipient }
+
+type IndianDetails struct {
+	LegalType     LegalType `json:"legalType"`
+	IFSCCode      string    `json:"ifscCode"`
+	AccountNumber string    `json:"accountNumber"`
+}
+
+func (IndianDetails) recipientType() RecipientType { return IndianRecipient }
+
+type EmailDetails struct {
+	Email string `json:"email"`
+}
+
+func (EmailDetails) recipientType() RecipientType { return EmailRecipient }
+
+type recipientRequest struct {
+	Profile           int              `json:"profile"`
+	AccountHolderName string           `json:"accountHolderName"`
+	Currency          string           `json:"currency"`
+	Type              RecipientType    `json:"type"`
+	Details           RecipientDetails `json:"details"`
+}
+
+type Recipient struct {
+	ID                int           `json:"id"`
+	Profile           int           `json:"profile"`
+	AccountHolderName string        `json:"accountHolderName"`
+	Type              RecipientType `json:"type"`
+	Country           string        `json:"country"`
+	Currency          string        `json:"currency"`
+	Active            bool          `json:"active"`
+	OwnedByCustomer   bool          `json:"ownedByCustomer"`
+	Details           struct {
+		LegalType     LegalType        `json:"legalType,omitempty"`
+		Email         string           `json:"email,omitempty"`
+		IBAN          string           `json:"IBAN,omitempty"`
+		BIC           string           `json:"BIC,omitempty"`
+		SortCode      string           `json:"sortCode,omitempty"`
+		ABARTN        string           `json:"abartn,omitempty"`
+		BSBCode       string           `json:"bsbCode,omitempty"`
+		IFSCCode      string           `json:"ifscCode,omitempty"`
+		AccountNumber string           `json:"accountNumber,omitempty"`
+		AccountType   AccountType      `json:"accountType,omitempty"`
+		Address       RecipientAddress `json:"address,omitempty"`
+	} `json:"details"`
+}
+
+func (p profile) RecipientRequest(currency, accountHolderName string, details RecipientDetails) (recipientRequest, error) {
+	if details == nil {
+		return recipientRequest{}, fmt.Errorf("recipient details are required")
+	}
+
+	if accountHolderName == "" {
+		return recipientRequest{}, fmt.Errorf("account holder name is required")
+	}
+
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return recipientRequest{}, err
+	}
+
+	r := recipientRequest{
+		Profile:           p.ID,
+		AccountHolderName: accountHolderName,
+		Currency:          string(c),
+		Type:              details.recipientType(),
+		Details:           details,
+	}
+
+	return r, nil
+}
+
+func (a *API) CreateRecipient(r recipientRequest, options ...ReqOption) (*Recipient, error) {
+	return a.CreateRecipientContext(context.Background(), r, options...)
+}
+
+func (a *API) CreateRecipientContext(ctx context.Context, r recipientRequest, options ...ReqOption) (*Recipient, error) {
+	if err := a.checkTarget(r.Currency); err != nil {
+		return nil, err
+	}
+
+	d := Recipient{}
+	if err := a.do(ctx, "v1/accounts", http.MethodPost, r, &d, options...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) Recipients(profileID int, currency string) ([]Recipient, error) {
+	return a.RecipientsContext(context.Background(), profileID, currency)
+}
+
+func (a *API) RecipientsContext(ctx context.Context, profileID int, currency string) ([]Recipient, error) {
+	q := urlpkg.Values{}
+	q.Set("profile", strconv.Itoa(profileID))
+	if currency != "" {
+		c, err := ParseCurrency(currency)
+		if err != nil {
+			return nil, err
+		}
+		q.Set("currency", string(c))
+	}
+
+	res := []Recipient{}
+	if err := a.do(ctx, "v1/accounts?"+q.Encode(), http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) RecipientByID(id int) (*Recipient, error) {
+	return a.RecipientByIDContext(context.Background(), id)
+}
+
+func (a *API) RecipientByIDContext(ctx context.Context, id int) (*Recipient, error) {
+	d := Recipient{}
+	url := fmt.Sprintf("v1/accounts/%d", id)
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) DeleteRecipient(id int, options ...ReqOption) error {
+	return a.DeleteRecipientContext(context.Background(), id, options...)
+}
+
+func (a *API) DeleteRecipientContext(ctx context.Context, id int, options ...ReqOption) error {
+	url := fmt.Sprintf("v1/accounts/%d", id)
+	return a.do(ctx, url, http.MethodDelete, nil, nil, options...)
+}
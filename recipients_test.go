@@ -0,0 +1,97 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRecipients(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/accounts":
+			req := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			details := req["details"].(map[string]interface{})
+			if req["type"] != "iban" || details["IBAN"] != "DE89370400440532013000" {
+				t.Errorf("unexpected request: %v", req)
+			}
+
+			w.Write([]byte(`{"id":13,"profile":1,"accountHolderName":"Ann","type":"iban","currency":"EUR","details":{"legalType":"PRIVATE","IBAN":"DE89370400440532013000"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/accounts":
+			if r.URL.Query().Get("profile") != "1" || r.URL.Query().Get("currency") != "GBP" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+
+			w.Write([]byte(`[{"id":14,"type":"sort_code","details":{"sortCode":"231470","accountNumber":"28821822"}}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/accounts/13":
+			w.Write([]byte(`{"id":13,"type":"iban"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/accounts/13":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	p := profile{ID: 1}
+
+	t.Run("create", func(t *testing.T) {
+		req, err := p.RecipientRequest("EUR", "Ann", IBANDetails{LegalType: PrivateLegalType, IBAN: "DE89370400440532013000"})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		r, err := api.CreateRecipient(req)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if r.ID != 13 || r.Details.IBAN != "DE89370400440532013000" {
+			t.Errorf("unexpected recipient: %#v", r)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := p.RecipientRequest("EUR", "Ann", nil); err == nil {
+			t.Errorf("expected an error without details")
+		}
+
+		if _, err := p.RecipientRequest("EUR", "", EmailDetails{Email: "ann@example.com"}); err == nil {
+			t.Errorf("expected an error without account holder name")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		r, err := api.Recipients(1, "gbp")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(r) != 1 || r[0].Details.SortCode != "231470" {
+			t.Errorf("unexpected recipients: %#v", r)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		r, err := api.RecipientByID(13)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if r.Type != IBANRecipient {
+			t.Errorf("expected type %v, but got %v", IBANRecipient, r.Type)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := api.DeleteRecipient(13); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+	})
+}
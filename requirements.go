@@ -0,0 +1,333 @@
+package transferwise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type AllowedValue struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type RequirementField struct {
+	Key                         string         `json:"key"`
+	Name                        string         `json:"name"`
+	Type                        string         `json:"type"`
+	RefreshRequirementsOnChange bool           `json:"refreshRequirementsOnChange"`
+	Required                    bool           `json:"required"`
+	DisplayFormat               string         `json:"displayFormat"`
+	Example                     string         `json:"example"`
+	MinLength                   *int           `json:"minLength"`
+	MaxLength                   *int           `json:"maxLength"`
+	ValidationRegexp            string         `json:"validationRegexp"`
+	ValuesAllowed               []AllowedValue `json:"valuesAllowed"`
+}
+
+type RequirementGroup struct {
+	Name  string             `json:"name"`
+	Group []RequirementField `json:"group"`
+}
+
+type Requirement struct {
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	UsageInfo string             `json:"usageInfo"`
+	Fields    []RequirementGroup `json:"fields"`
+}
+
+type Requirements []Requirement
+
+func (r Requirements) ByType(t string) *Requirement {
+	for i := range r {
+		if r[i].Type == t {
+			return &r[i]
+		}
+	}
+
+	return nil
+}
+
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	s := make([]string, len(v))
+	for i, err := range v {
+		s[i] = fmt.Sprintf("%s: %s", err.Path, err.Message)
+	}
+
+	return "validation failed: " + strings.Join(s, "; ")
+}
+
+func (v ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (v ValidationErrors) FieldErrors() map[string][]FieldError {
+	return APIError{Errors: v}.FieldErrors()
+}
+
+// lookup resolves dotted requirement keys such as address.country in a nested payload.
+func lookup(m map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	var v interface{} = m
+	for _, p := range parts {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if v, ok = o[p]; !ok || v == nil {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+func (f RequirementField) validate(v interface{}, present bool) *FieldError {
+	s := ""
+	if present {
+		s = fmt.Sprint(v)
+	}
+
+	if s == "" {
+		if f.Required {
+			return &FieldError{Code: "REQUIRED", Message: fmt.Sprintf("%s is required", f.Name), Path: f.Key}
+		}
+		return nil
+	}
+
+	if f.MinLength != nil && len([]rune(s)) < *f.MinLength {
+		return &FieldError{Code: "TOO_SHORT", Message: fmt.Sprintf("%s should be at least %d characters", f.Name, *f.MinLength), Path: f.Key, Arguments: []interface{}{*f.MinLength}}
+	}
+
+	if f.MaxLength != nil && len([]rune(s)) > *f.MaxLength {
+		return &FieldError{Code: "TOO_LONG", Message: fmt.Sprintf("%s should be at most %d characters", f.Name, *f.MaxLength), Path: f.Key, Arguments: []interface{}{*f.MaxLength}}
+	}
+
+	// Patterns that Go can't compile are left to the server to validate.
+	if re, err := regexp.Compile(f.ValidationRegexp); f.ValidationRegexp != "" && err == nil && !re.MatchString(s) {
+		return &FieldError{Code: "PATTERN", Message: fmt.Sprintf("%s has an invalid format", f.Name), Path: f.Key, Arguments: []interface{}{f.ValidationRegexp}}
+	}
+
+	if len(f.ValuesAllowed) > 0 {
+		for _, a := range f.ValuesAllowed {
+			if a.Key == s {
+				return nil
+			}
+		}
+		return &FieldError{Code: "NOT_ALLOWED", Message: fmt.Sprintf("%s has a value that isn't allowed", f.Name), Path: f.Key, Arguments: []interface{}{s}}
+	}
+
+	return nil
+}
+
+func (r Requirement) fields() []RequirementField {
+	f := []RequirementField{}
+	for _, g := range r.Fields {
+		f = append(f, g.Group...)
+	}
+	return f
+}
+
+func (r Requirement) Validate(payload map[string]interface{}) error {
+	errs := ValidationErrors{}
+	for _, f := range r.fields() {
+		v, ok := lookup(payload, f.Key)
+		if err := f.validate(v, ok); err != nil {
+			errs = append(errs, *err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// needsRefresh reports whether any field marked with refreshRequirementsOnChange
+// has a different value in next than in prev.
+func (r Requirement) needsRefresh(prev, next map[string]interface{}) bool {
+	for _, f := range r.fields() {
+		if !f.RefreshRequirementsOnChange {
+			continue
+		}
+
+		pv, _ := lookup(prev, f.Key)
+		nv, _ := lookup(next, f.Key)
+		if fmt.Sprint(pv) != fmt.Sprint(nv) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toPayload(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (a *API) QuoteAccountRequirements(quoteID int) (Requirements, error) {
+	return a.QuoteAccountRequirementsContext(context.Background(), quoteID)
+}
+
+func (a *API) QuoteAccountRequirementsContext(ctx context.Context, quoteID int) (Requirements, error) {
+	res := Requirements{}
+	url := fmt.Sprintf("v1/quotes/%d/account-requirements", quoteID)
+	if err := a.do(ctx, url, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type accountRequirementsRequest struct {
+	Type              RecipientType          `json:"type"`
+	AccountHolderName string                 `json:"accountHolderName,omitempty"`
+	Details           map[string]interface{} `json:"details"`
+}
+
+func (a *API) RefreshAccountRequirements(quoteID int, t RecipientType, details map[string]interface{}) (Requirements, error) {
+	return a.RefreshAccountRequirementsContext(context.Background(), quoteID, t, details)
+}
+
+func (a *API) RefreshAccountRequirementsContext(ctx context.Context, quoteID int, t RecipientType, details map[string]interface{}) (Requirements, error) {
+	res := Requirements{}
+	url := fmt.Sprintf("v1/quotes/%d/account-requirements", quoteID)
+	if err := a.do(ctx, url, http.MethodPost, accountRequirementsRequest{Type: t, Details: details}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type AccountRequirements struct {
+	Requirements Requirements
+
+	api     *API
+	quoteID int
+	details map[RecipientType]map[string]interface{}
+}
+
+func (a *API) AccountRequirements(q QuoteResponse) (*AccountRequirements, error) {
+	return a.AccountRequirementsContext(context.Background(), q)
+}
+
+func (a *API) AccountRequirementsContext(ctx context.Context, q QuoteResponse) (*AccountRequirements, error) {
+	r, err := a.QuoteAccountRequirementsContext(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountRequirements{
+		Requirements: r,
+		api:          a,
+		quoteID:      q.ID,
+		details:      map[RecipientType]map[string]interface{}{},
+	}, nil
+}
+
+func (r *AccountRequirements) Update(t RecipientType, details map[string]interface{}) (bool, error) {
+	return r.UpdateContext(context.Background(), t, details)
+}
+
+// UpdateContext records the current details for an account type and re-queries
+// the requirements when a field marked with refreshRequirementsOnChange changed.
+func (r *AccountRequirements) UpdateContext(ctx context.Context, t RecipientType, details map[string]interface{}) (bool, error) {
+	req := r.Requirements.ByType(string(t))
+	if req == nil {
+		return false, fmt.Errorf("no requirements for account type %v", t)
+	}
+
+	prev := r.details[t]
+	r.details[t] = details
+	if !req.needsRefresh(prev, details) {
+		return false, nil
+	}
+
+	res, err := r.api.RefreshAccountRequirementsContext(ctx, r.quoteID, t, details)
+	if err != nil {
+		return false, err
+	}
+
+	r.Requirements = res
+	return true, nil
+}
+
+func (r *AccountRequirements) Validate(t RecipientType, details map[string]interface{}) error {
+	req := r.Requirements.ByType(string(t))
+	if req == nil {
+		return fmt.Errorf("no requirements for account type %v", t)
+	}
+
+	return req.Validate(details)
+}
+
+func (r *AccountRequirements) ValidateRecipient(rr recipientRequest) error {
+	details, err := toPayload(rr.Details)
+	if err != nil {
+		return fmt.Errorf("error converting recipient details: %w", err)
+	}
+
+	return r.Validate(rr.Type, details)
+}
+
+func (a *API) TransferRequirements(r transferRequest) (Requirements, error) {
+	return a.TransferRequirementsContext(context.Background(), r)
+}
+
+func (a *API) TransferRequirementsContext(ctx context.Context, r transferRequest) (Requirements, error) {
+	res := Requirements{}
+	if err := a.do(ctx, "v1/transfer-requirements", http.MethodPost, r, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) ValidateTransfer(r transferRequest) error {
+	return a.ValidateTransferContext(context.Background(), r)
+}
+
+// ValidateTransferContext fetches the requirements for the transfer as it is
+// filled in, so fields depending on refreshRequirementsOnChange fields are
+// included, and validates the transfer details against them.
+func (a *API) ValidateTransferContext(ctx context.Context, r transferRequest) error {
+	req, err := a.TransferRequirementsContext(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	details, err := toPayload(r.Details)
+	if err != nil {
+		return fmt.Errorf("error converting transfer details: %w", err)
+	}
+
+	errs := ValidationErrors{}
+	for _, rq := range req {
+		var ve ValidationErrors
+		if err := rq.Validate(details); errors.As(err, &ve) {
+			errs = append(errs, ve...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const sortCodeRequirements = `[{"type":"sort_code","title":"Local bank account","fields":[
+	{"name":"Recipient type","group":[{"key":"legalType","name":"Recipient type","type":"select","refreshRequirementsOnChange":false,"required":true,"valuesAllowed":[{"key":"PRIVATE","name":"Person"},{"key":"BUSINESS","name":"Business"}]}]},
+	{"name":"Sort code","group":[{"key":"sortCode","name":"Sort code","type":"text","required":true,"minLength":6,"maxLength":8,"validationRegexp":"^[0-9]{2}-?[0-9]{2}-?[0-9]{2}$"}]},
+	{"name":"Account number","group":[{"key":"accountNumber","name":"Account number","type":"text","required":true,"minLength":8,"maxLength":8}]},
+	{"name":"Country","group":[{"key":"address.country","name":"Country","type":"select","refreshRequirementsOnChange":true,"required":true}]}
+]},{"type":"iban","fields":[{"name":"IBAN","group":[{"key":"IBAN","name":"IBAN","type":"text","required":true,"minLength":22,"maxLength":22}]}]}]`
+
+func TestAccountRequirements(t *testing.T) {
+	refreshes := 0
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/quotes/42/account-requirements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(sortCodeRequirements))
+			return
+		}
+
+		refreshes++
+		req := accountRequirementsRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Type != SortCodeRecipient {
+			t.Errorf("unexpected refresh request: %#v", req)
+		}
+
+		w.Write([]byte(`[{"type":"sort_code","fields":[
+			{"name":"Country","group":[{"key":"address.country","name":"Country","type":"select","refreshRequirementsOnChange":true,"required":true}]},
+			{"name":"State","group":[{"key":"address.state","name":"State","type":"select","required":true,"valuesAllowed":[{"key":"AZ","name":"Arizona"}]}]}
+		]}]`))
+	}))
+
+	ar, err := api.AccountRequirements(QuoteResponse{ID: 42})
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		rr, _ := profile{ID: 1}.RecipientRequest("GBP", "Ann", SortCodeDetails{LegalType: PrivateLegalType, SortCode: "23-14-70", AccountNumber: "28821822"})
+		details, _ := toPayload(rr.Details)
+		details["address"] = map[string]interface{}{"country": "GB"}
+
+		if err := ar.Validate(SortCodeRecipient, details); err != nil {
+			t.Errorf("expected to pass, but got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		rr, _ := profile{ID: 1}.RecipientRequest("GBP", "Ann", SortCodeDetails{LegalType: "NOBODY", SortCode: "23147", AccountNumber: "2882182x1"})
+
+		err := ar.ValidateRecipient(rr)
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("expected a validation error, but got %v", err)
+		}
+
+		var ve ValidationErrors
+		errors.As(err, &ve)
+		f := ve.FieldErrors()
+		for key, code := range map[string]string{"legalType": "NOT_ALLOWED", "sortCode": "TOO_SHORT", "accountNumber": "TOO_LONG", "address.country": "REQUIRED"} {
+			if len(f[key]) != 1 || f[key][0].Code != code {
+				t.Errorf("%s: expected %s, but got %v", key, code, f[key])
+			}
+		}
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		details := map[string]interface{}{"legalType": "PRIVATE", "sortCode": "23x14x70", "accountNumber": "28821822", "address": map[string]interface{}{"country": "GB"}}
+
+		var ve ValidationErrors
+		if err := ar.Validate(SortCodeRecipient, details); !errors.As(err, &ve) || len(ve) != 1 || ve[0].Code != "PATTERN" {
+			t.Errorf("expected a pattern error, but got %v", err)
+		}
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		details := map[string]interface{}{"legalType": "PRIVATE"}
+		changed, err := ar.Update(SortCodeRecipient, details)
+		if err != nil || changed {
+			t.Fatalf("expected no refresh without country, but got %v, %v", changed, err)
+		}
+
+		details = map[string]interface{}{"legalType": "PRIVATE", "address": map[string]interface{}{"country": "US"}}
+		changed, err = ar.Update(SortCodeRecipient, details)
+		if err != nil || !changed || refreshes != 1 {
+			t.Fatalf("expected a refresh after changing country, but got %v, %v", changed, err)
+		}
+
+		if changed, _ := ar.Update(SortCodeRecipient, details); changed || refreshes != 1 {
+			t.Errorf("expected no refresh for unchanged details")
+		}
+
+		err = ar.Validate(SortCodeRecipient, details)
+		var ve ValidationErrors
+		if !errors.As(err, &ve) || len(ve.FieldErrors()["address.state"]) != 1 {
+			t.Errorf("expected the refreshed requirements to require a state, but got %v", err)
+		}
+	})
+
+	t.Run("unknownType", func(t *testing.T) {
+		if err := ar.Validate(EmailRecipient, nil); err == nil {
+			t.Errorf("expected an error for an unknown type")
+		}
+	})
+}
+
+func TestTransferRequirements(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/transfer-requirements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		req := transferRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		sub := ""
+		if req.Details.TransferPurpose == "verification.transfers.purpose.other" {
+			sub = `,{"name":"Sub purpose","group":[{"key":"transferPurposeSubTransferPurpose","name":"Sub purpose","type":"select","required":true,"valuesAllowed":[{"key":"gift","name":"Gift"}]}]}`
+		}
+
+		w.Write([]byte(`[{"type":"transfer","fields":[
+			{"name":"Reference","group":[{"key":"reference","name":"Reference","type":"text","required":false,"maxLength":10}]},
+			{"name":"Purpose","group":[{"key":"transferPurpose","name":"Purpose","type":"select","refreshRequirementsOnChange":true,"required":true,"valuesAllowed":[{"key":"verification.transfers.purpose.pay.bills","name":"Bills"},{"key":"verification.transfers.purpose.other","name":"Other"}]}]},
+			{"name":"Source of funds","group":[{"key":"sourceOfFunds","name":"Source of funds","type":"select","required":true,"valuesAllowed":[{"key":"verification.source.of.funds.salary","name":"Salary"}]}]}` + sub + `
+		]}]`))
+	}))
+
+	q := QuoteResponse{ID: 42}
+
+	t.Run("valid", func(t *testing.T) {
+		r, _ := q.TransferRequest(13, "", TransferDetails{
+			Reference:       "invoice 1",
+			TransferPurpose: "verification.transfers.purpose.pay.bills",
+			SourceOfFunds:   "verification.source.of.funds.salary",
+		})
+
+		if err := api.ValidateTransfer(r); err != nil {
+			t.Errorf("expected to pass, but got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r, _ := q.TransferRequest(13, "", TransferDetails{
+			Reference:       "a reference that is too long",
+			TransferPurpose: "verification.transfers.purpose.other",
+		})
+
+		err := api.ValidateTransfer(r)
+
+		var ve ValidationErrors
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected validation errors, but got %v", err)
+		}
+
+		f := ve.FieldErrors()
+		for _, key := range []string{"reference", "sourceOfFunds", "transferPurposeSubTransferPurpose"} {
+			if len(f[key]) != 1 {
+				t.Errorf("expected an error for %s, but got %v", key, f)
+			}
+		}
+	})
+}
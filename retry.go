@@ -0,0 +1,138 @@
+package transferwise
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const idempotenceHeader = "X-idempotence-uuid"
+
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  250 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+func WithRetry(p RetryPolicy) APIOption {
+	return func(a *API) error {
+		if p.MaxAttempts < 1 {
+			return fmt.Errorf("max attempts should be at least 1")
+		}
+
+		if p.MinBackoff < 0 || p.MaxBackoff < p.MinBackoff {
+			return fmt.Errorf("invalid backoff range %v - %v", p.MinBackoff, p.MaxBackoff)
+		}
+
+		a.retry = &p
+		return nil
+	}
+}
+
+func idempotent(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return r.Header.Get(idempotenceHeader) != ""
+}
+
+func (p RetryPolicy) retryable(req *http.Request, res *http.Response, err error) bool {
+	if !idempotent(req) {
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	// Don't wait longer than MaxBackoff, however long the server asks for.
+	if d, ok := retryAfter(res); ok && d > p.MaxBackoff {
+		return false
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	h := res.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(h); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if d, ok := retryAfter(res); ok {
+		return d
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	// Equal jitter: wait at least half the backoff, plus a random part of the rest.
+	if half := d / 2; half > 0 {
+		d = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+
+	return d
+}
+
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (a *API) retryError(attempts int, err error) error {
+	if a.retry == nil {
+		return err
+	}
+
+	var re *RetryError
+	if errors.As(err, &re) {
+		return err
+	}
+
+	return &RetryError{Attempts: attempts, Err: err}
+}
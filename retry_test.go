@@ -0,0 +1,188 @@
+package transferwise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	failing := func(failures int32, status int, calls *int32) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(calls, 1) <= failures {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		})
+	}
+
+	t.Run("recovers", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, failing(2, http.StatusServiceUnavailable, &calls), WithRetry(p))
+
+		q, err := api.QuoteByID(1)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if q.ID != 1 || calls != 3 {
+			t.Errorf("expected quote after 3 calls, but got %d calls", calls)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, failing(5, http.StatusTooManyRequests, &calls), WithRetry(p))
+
+		_, err := api.QuoteByID(1)
+
+		var re *RetryError
+		if !errors.As(err, &re) {
+			t.Fatalf("expected a RetryError, but got %v", err)
+		}
+
+		if re.Attempts != 3 || calls != 3 {
+			t.Errorf("expected 3 attempts, but got %d (%d calls)", re.Attempts, calls)
+		}
+
+		var ae APIError
+		if !errors.As(err, &ae) {
+			t.Errorf("expected the APIError to be wrapped, but got %v", err)
+		}
+	})
+
+	t.Run("nonIdempotent", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, failing(1, http.StatusBadGateway, &calls), WithRetry(p))
+
+		if _, err := api.Quote(quoteRequest{}); err == nil {
+			t.Errorf("expected an error")
+		}
+
+		if calls != 1 {
+			t.Errorf("expected POST not to be retried, but got %d calls", calls)
+		}
+	})
+
+	t.Run("sameKey", func(t *testing.T) {
+		keys := []string{}
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get(idempotenceHeader))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		}), WithRetry(p))
+
+		req, err := QuoteResponse{ID: 1}.TransferRequest(13, "", TransferDetails{})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if _, err := api.CreateTransfer(req); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("expected POST to be retried with the same idempotency key, but got %v", keys)
+		}
+	})
+
+	t.Run("noKey", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com", nil)
+		res := &http.Response{StatusCode: http.StatusBadGateway}
+		if p.retryable(req, res, nil) {
+			t.Errorf("expected POST without idempotency key not to be retryable")
+		}
+	})
+
+	t.Run("idempotencyKey", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, failing(1, http.StatusBadGateway, &calls), WithRetry(p))
+
+		acc := BalanceAccount{ID: 1, ProfileID: 1, Balances: []Balance{{Currency: "EUR"}}}
+		q := QuoteResponse{Profile: 1, Source: "EUR", Type: BalanceConversion}
+		if _, err := api.ConvertBalance(acc, q); err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("expected POST with idempotency key to be retried, but got %d calls", calls)
+		}
+	})
+
+	t.Run("clientError", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, failing(1, http.StatusBadRequest, &calls), WithRetry(p))
+
+		if _, err := api.QuoteByID(1); err == nil {
+			t.Errorf("expected an error")
+		}
+
+		if calls != 1 {
+			t.Errorf("expected 4xx not to be retried, but got %d calls", calls)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var calls int32
+		slow := p
+		slow.MinBackoff, slow.MaxBackoff = time.Hour, time.Hour
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}), WithRetry(slow))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if _, err := api.QuoteByIDContext(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, but got %v", err)
+		}
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		p := RetryPolicy{MaxAttempts: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+		for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 8: time.Second} {
+			d := p.backoff(attempt, nil)
+			if d < max/2 || d > max {
+				t.Errorf("attempt %d: expected backoff between %v and %v, but got %v", attempt, max/2, max, d)
+			}
+		}
+
+		res := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+		if d := p.backoff(1, res); d != time.Second {
+			t.Errorf("expected Retry-After to be honoured, but got %v", d)
+		}
+	})
+
+	t.Run("longRetryAfter", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}), WithRetry(p))
+
+		_, err := api.QuoteByID(1)
+
+		var ae APIError
+		if !errors.As(err, &ae) || calls != 1 {
+			t.Errorf("expected the error without waiting for Retry-After, but got %v after %d calls", err, calls)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := New("a", WithRetry(RetryPolicy{})); err == nil {
+			t.Errorf("expected an error for zero attempts")
+		}
+	})
+}
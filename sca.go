@@ -0,0 +1,102 @@
+package transferwise
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const (
+	approvalHeader  = "X-2fa-Approval"
+	signatureHeader = "X-Signature"
+)
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %v", err)
+	}
+
+	rk, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA private key, but got %T", k)
+	}
+
+	return rk, nil
+}
+
+func WithSigningKey(pemKey []byte) APIOption {
+	return func(a *API) error {
+		k, err := parsePrivateKey(pemKey)
+		if err != nil {
+			return err
+		}
+
+		a.signingKey = k
+		return nil
+	}
+}
+
+func WithSigningKeyFile(path string) APIOption {
+	return func(a *API) error {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading signing key: %w", err)
+		}
+
+		return WithSigningKey(b)(a)
+	}
+}
+
+func sign(k *rsa.PrivateKey, ott string) (string, error) {
+	h := sha256.Sum256([]byte(ott))
+	s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, h[:])
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(s), nil
+}
+
+func withApproval(ott, signature string) ReqOption {
+	return func(r *http.Request) error {
+		r.Header.Set(approvalHeader, ott)
+		r.Header.Set(signatureHeader, signature)
+		return nil
+	}
+}
+
+func (a *API) approve(ctx context.Context, url string, req *http.Request, ott string) (*http.Response, int, error) {
+	if a.signingKey == nil {
+		return nil, 0, fmt.Errorf("%w: no signing key configured", ErrSCARequired)
+	}
+
+	s, err := sign(a.signingKey, ott)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error signing one time token: %w", err)
+	}
+
+	r := req.Clone(ctx)
+	if err := withApproval(ott, s)(r); err != nil {
+		return nil, 0, err
+	}
+
+	return a.send(ctx, url, r)
+}
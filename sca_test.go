@@ -0,0 +1,111 @@
+package transferwise
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func newTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	b := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+	return k, b
+}
+
+func TestFundTransfer(t *testing.T) {
+	k, pemKey := newTestKey(t)
+	const ott = "be2f6579-9426-480b-9cb7-d8f1116cc8b9"
+
+	calls := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/profiles/1/transfers/2/payments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if k := r.Header.Get(idempotenceHeader); k != derivedKey("funding", "1", "2") {
+			t.Errorf("expected an idempotence uuid derived from the transfer, but got %q", k)
+		}
+
+		sig := r.Header.Get("X-Signature")
+		if sig == "" {
+			w.Header().Set("X-2FA-Approval-Result", "REJECTED")
+			w.Header().Set("X-2FA-Approval", ott)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.Header.Get("X-2FA-Approval") != ott {
+			t.Errorf("expected the one time token to be replayed")
+		}
+
+		b, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			t.Errorf("expected to pass, but got %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		hash := sha256.Sum256([]byte(ott))
+		if err := rsa.VerifyPKCS1v15(&k.PublicKey, crypto.SHA256, hash[:], b); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Write([]byte(`{"type":"BALANCE","status":"COMPLETED","balanceTransactionId":11}`))
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		calls = 0
+		api := newTestAPI(t, h, WithSigningKey(pemKey))
+
+		f, err := api.FundTransfer(1, 2)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if f.Status != "COMPLETED" || f.BalanceTransactionID != 11 {
+			t.Errorf("unexpected funding result: %#v", f)
+		}
+
+		if calls != 2 {
+			t.Errorf("expected 2 calls, but got %d", calls)
+		}
+	})
+
+	t.Run("noKey", func(t *testing.T) {
+		calls = 0
+		api := newTestAPI(t, h)
+
+		_, err := api.FundTransfer(1, 2)
+		if !errors.Is(err, ErrSCARequired) || !errors.Is(err, ErrForbidden) {
+			t.Errorf("expected an SCA required and forbidden error, but got %v", err)
+		}
+
+		var ae APIError
+		if !errors.As(err, &ae) || ae.StatusCode != http.StatusForbidden {
+			t.Errorf("expected the 403 APIError to be wrapped, but got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("expected 1 call, but got %d", calls)
+		}
+	})
+
+	t.Run("invalidKey", func(t *testing.T) {
+		if _, err := New("a", WithSigningKey([]byte("nope"))); err == nil {
+			t.Errorf("expected an error for an invalid key")
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+)
+
+type StatementFormat string
+
+var (
+	JSONStatement    StatementFormat = "json"
+	CSVStatement     StatementFormat = "csv"
+	PDFStatement     StatementFormat = "pdf"
+	CAMT053Statement StatementFormat = "xml"
+	MT940Statement   StatementFormat = "mt940"
+)
+
+type TransactionType string
+
+var (
+	Credit TransactionType = "CREDIT"
+	Debit  TransactionType = "DEBIT"
+)
+
+type StatementTransaction struct {
+	Type    TransactionType `json:"type"`
+	Date    time.Time       `json:"date"`
+	Amount  Amount          `json:"amount"`
+	Fees    Amount          `json:"totalFees"`
+	Details struct {
+		Type             string  `json:"type"`
+		Description      string  `json:"description"`
+		Amount           Amount  `json:"amount"`
+		SourceAmount     Amount  `json:"sourceAmount"`
+		TargetAmount     Amount  `json:"targetAmount"`
+		Fee              Amount  `json:"fee"`
+		ExchangeRate     Decimal `json:"exchangeRate"`
+		SenderName       string  `json:"senderName"`
+		SenderAccount    string  `json:"senderAccount"`
+		PaymentReference string  `json:"paymentReference"`
+		Category         string  `json:"category"`
+		Merchant         *struct {
+			Name     string `json:"name"`
+			City     string `json:"city"`
+			Country  string `json:"country"`
+			Category string `json:"category"`
+		} `json:"merchant"`
+	} `json:"details"`
+	ExchangeDetails *struct {
+		ForAmount Amount  `json:"forAmount"`
+		Rate      Decimal `json:"rate"`
+	} `json:"exchangeDetails"`
+	RunningBalance  Amount `json:"runningBalance"`
+	ReferenceNumber string `json:"referenceNumber"`
+}
+
+type Statement struct {
+	AccountHolder struct {
+		Type      string           `json:"type"`
+		Address   RecipientAddress `json:"address"`
+		FirstName string           `json:"firstName"`
+		LastName  string           `json:"lastName"`
+	} `json:"accountHolder"`
+	Issuer struct {
+		Name      string `json:"name"`
+		FirstLine string `json:"firstLine"`
+		City      string `json:"city"`
+		PostCode  string `json:"postCode"`
+		StateCode string `json:"stateCode"`
+		Country   string `json:"country"`
+	} `json:"issuer"`
+	Transactions          []StatementTransaction `json:"transactions"`
+	EndOfStatementBalance Amount                 `json:"endOfStatementBalance"`
+	Query                 struct {
+		IntervalStart time.Time `json:"intervalStart"`
+		IntervalEnd   time.Time `json:"intervalEnd"`
+		Currency      string    `json:"currency"`
+		AccountID     int       `json:"accountId"`
+	} `json:"query"`
+}
+
+func (s Statement) TotalFees() Amount {
+	f := NewAmount(Decimal{}, s.Query.Currency)
+	for _, t := range s.Transactions {
+		f.Value = f.Value.Add(t.Fees.Value)
+	}
+
+	return f
+}
+
+func statementURL(profileID, accountID int, currency string, start, end time.Time, f StatementFormat) (string, error) {
+	if start.IsZero() || end.IsZero() || !start.Before(end) {
+		return "", fmt.Errorf("invalid statement interval %v - %v", start, end)
+	}
+
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return "", err
+	}
+
+	q := urlpkg.Values{}
+	q.Set("currency", string(c))
+	q.Set("intervalStart", start.UTC().Format("2006-01-02T15:04:05.000Z"))
+	q.Set("intervalEnd", end.UTC().Format("2006-01-02T15:04:05.000Z"))
+	q.Set("type", "COMPACT")
+
+	return fmt.Sprintf("v3/profiles/%d/borderless-accounts/%d/statement.%s?%s", profileID, accountID, f, q.Encode()), nil
+}
+
+func (a *API) Statement(profileID, accountID int, currency string, start, end time.Time) (*Statement, error) {
+	return a.StatementContext(context.Background(), profileID, accountID, currency, start, end)
+}
+
+func (a *API) StatementContext(ctx context.Context, profileID, accountID int, currency string, start, end time.Time) (*Statement, error) {
+	url, err := statementURL(profileID, accountID, currency, start, end, JSONStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	d := Statement{}
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) RawStatement(profileID, accountID int, currency string, start, end time.Time, f StatementFormat) ([]byte, error) {
+	return a.RawStatementContext(context.Background(), profileID, accountID, currency, start, end, f)
+}
+
+func (a *API) RawStatementContext(ctx context.Context, profileID, accountID int, currency string, start, end time.Time, f StatementFormat) ([]byte, error) {
+	url, err := statementURL(profileID, accountID, currency, start, end, f)
+	if err != nil {
+		return nil, err
+	}
+
+	d := []byte{}
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,78 @@
+package transferwise
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStatements(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("currency") != "EUR" || q.Get("intervalStart") != "2018-03-01T00:00:00.000Z" || q.Get("intervalEnd") != "2018-04-01T00:00:00.000Z" {
+			t.Errorf("unexpected query: %v", r.URL.RawQuery)
+		}
+
+		switch r.URL.Path {
+		case "/v3/profiles/1/borderless-accounts/64/statement.json":
+			w.Write([]byte(`{
+				"accountHolder":{"type":"PERSONAL","firstName":"Oliver","lastName":"Wilson"},
+				"transactions":[
+					{"type":"DEBIT","date":"2018-03-10T08:47:05.832Z","amount":{"value":-9.6,"currency":"EUR"},"totalFees":{"value":0.6,"currency":"EUR"},
+					 "details":{"type":"CARD","description":"Card transaction","paymentReference":"ref 1"},
+					 "runningBalance":{"value":90.4,"currency":"EUR"},"referenceNumber":"CARD-249281"},
+					{"type":"CREDIT","date":"2018-03-11T08:47:05.832Z","amount":{"value":10,"currency":"EUR"},"totalFees":{"value":0.4,"currency":"EUR"},
+					 "details":{"type":"DEPOSIT","senderName":"Ann"},
+					 "runningBalance":{"value":100.4,"currency":"EUR"},"referenceNumber":"TRANSFER-34188888"}
+				],
+				"endOfStatementBalance":{"value":100.4,"currency":"EUR"},
+				"query":{"currency":"EUR","accountId":64}
+			}`))
+		case "/v3/profiles/1/borderless-accounts/64/statement.csv":
+			w.Write([]byte("TransferWise ID,Date,Amount\n"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	start := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	t.Run("json", func(t *testing.T) {
+		s, err := api.Statement(1, 64, "EUR", start, end)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(s.Transactions) != 2 {
+			t.Fatalf("expected 2 transactions, but got %d", len(s.Transactions))
+		}
+
+		tr := s.Transactions[0]
+		if tr.Type != Debit || !tr.RunningBalance.Value.Equal(MustParseDecimal("90.4")) || tr.ReferenceNumber != "CARD-249281" || tr.Details.PaymentReference != "ref 1" {
+			t.Errorf("unexpected transaction: %#v", tr)
+		}
+
+		if f := s.TotalFees(); !f.Value.Equal(NewDecimal(1, 0)) {
+			t.Errorf("expected total fees of 1.0, but got %v", f)
+		}
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		b, err := api.RawStatement(1, 64, "EUR", start, end, CSVStatement)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if string(b) != "TransferWise ID,Date,Amount\n" {
+			t.Errorf("unexpected csv: %q", b)
+		}
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		if _, err := api.Statement(1, 64, "EUR", end, start); err == nil {
+			t.Errorf("expected an error for an inverted interval")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type DeliveryVersion string
+
+var (
+	DeliveryV2 DeliveryVersion = "2.0.0"
+	DeliveryV3 DeliveryVersion = "3.0.0"
+)
+
+type SubscriptionScope struct {
+	path string
+}
+
+func ApplicationScope(clientKey string) SubscriptionScope {
+	return SubscriptionScope{path: fmt.Sprintf("v3/applications/%s/subscriptions", clientKey)}
+}
+
+func ProfileScope(profileID int) SubscriptionScope {
+	return SubscriptionScope{path: fmt.Sprintf("v3/profiles/%d/subscriptions", profileID)}
+}
+
+type SubscriptionDelivery struct {
+	Version DeliveryVersion `json:"version"`
+	URL     string          `json:"url"`
+}
+
+type SubscriptionRequest struct {
+	Name      string               `json:"name"`
+	TriggerOn EventType            `json:"trigger_on"`
+	Delivery  SubscriptionDelivery `json:"delivery"`
+}
+
+type Subscription struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	TriggerOn EventType            `json:"trigger_on"`
+	Delivery  SubscriptionDelivery `json:"delivery"`
+	Scope     struct {
+		Domain string `json:"domain"`
+		ID     string `json:"id"`
+	} `json:"scope"`
+	CreatedBy struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s Subscription) matches(r SubscriptionRequest) bool {
+	return s.TriggerOn == r.TriggerOn && s.Delivery == r.Delivery
+}
+
+func (a *API) CreateSubscription(s SubscriptionScope, r SubscriptionRequest, options ...ReqOption) (*Subscription, error) {
+	return a.CreateSubscriptionContext(context.Background(), s, r, options...)
+}
+
+func (a *API) CreateSubscriptionContext(ctx context.Context, s SubscriptionScope, r SubscriptionRequest, options ...ReqOption) (*Subscription, error) {
+	d := Subscription{}
+	if err := a.do(ctx, s.path, http.MethodPost, r, &d, options...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) Subscriptions(s SubscriptionScope) ([]Subscription, error) {
+	return a.SubscriptionsContext(context.Background(), s)
+}
+
+func (a *API) SubscriptionsContext(ctx context.Context, s SubscriptionScope) ([]Subscription, error) {
+	res := []Subscription{}
+	if err := a.do(ctx, s.path, http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) SubscriptionByID(s SubscriptionScope, id string) (*Subscription, error) {
+	return a.SubscriptionByIDContext(context.Background(), s, id)
+}
+
+func (a *API) SubscriptionByIDContext(ctx context.Context, s SubscriptionScope, id string) (*Subscription, error) {
+	d := Subscription{}
+	if err := a.do(ctx, s.path+"/"+id, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) DeleteSubscription(s SubscriptionScope, id string, options ...ReqOption) error {
+	return a.DeleteSubscriptionContext(context.Background(), s, id, options...)
+}
+
+func (a *API) DeleteSubscriptionContext(ctx context.Context, s SubscriptionScope, id string, options ...ReqOption) error {
+	return a.do(ctx, s.path+"/"+id, http.MethodDelete, nil, nil, options...)
+}
+
+func (a *API) ReconcileSubscriptions(s SubscriptionScope, desired []SubscriptionRequest) (created []Subscription, deleted []Subscription, err error) {
+	return a.ReconcileSubscriptionsContext(context.Background(), s, desired)
+}
+
+func (a *API) ReconcileSubscriptionsContext(ctx context.Context, s SubscriptionScope, desired []SubscriptionRequest) (created []Subscription, deleted []Subscription, err error) {
+	existing, err := a.SubscriptionsContext(ctx, s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kept := make([]bool, len(existing))
+	for _, r := range desired {
+		found := false
+		for i, sub := range existing {
+			if !kept[i] && sub.matches(r) {
+				kept[i] = true
+				found = true
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		sub, err := a.CreateSubscriptionContext(ctx, s, r)
+		if err != nil {
+			return created, deleted, err
+		}
+		created = append(created, *sub)
+	}
+
+	for i, sub := range existing {
+		if kept[i] {
+			continue
+		}
+
+		if err := a.DeleteSubscriptionContext(ctx, s, sub.ID); err != nil {
+			return created, deleted, err
+		}
+		deleted = append(deleted, sub)
+	}
+
+	return created, deleted, nil
+}
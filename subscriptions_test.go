@@ -0,0 +1,107 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSubscriptions(t *testing.T) {
+	mu := sync.Mutex{}
+	subs := map[string]Subscription{
+		"a": {ID: "a", TriggerOn: TransferStateChangeEvent, Delivery: SubscriptionDelivery{Version: DeliveryV2, URL: "https://example.com/hook"}},
+		"b": {ID: "b", TriggerOn: BalanceCreditEvent, Delivery: SubscriptionDelivery{Version: DeliveryV2, URL: "https://old.example.com/hook"}},
+	}
+	next := 0
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		const base = "/v3/profiles/1/subscriptions"
+		if !strings.HasPrefix(r.URL.Path, base) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, base), "/")
+		switch {
+		case r.Method == http.MethodGet && id == "":
+			res := []Subscription{}
+			for _, s := range subs {
+				res = append(res, s)
+			}
+			json.NewEncoder(w).Encode(res)
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(subs[id])
+		case r.Method == http.MethodPost:
+			req := SubscriptionRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			next++
+			s := Subscription{ID: string(rune('c' + next - 1)), Name: req.Name, TriggerOn: req.TriggerOn, Delivery: req.Delivery}
+			subs[s.ID] = s
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(s)
+		case r.Method == http.MethodDelete:
+			delete(subs, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	s := ProfileScope(1)
+	desired := []SubscriptionRequest{
+		{Name: "transfers", TriggerOn: TransferStateChangeEvent, Delivery: SubscriptionDelivery{Version: DeliveryV2, URL: "https://example.com/hook"}},
+		{Name: "credits", TriggerOn: BalanceCreditEvent, Delivery: SubscriptionDelivery{Version: DeliveryV2, URL: "https://example.com/hook"}},
+	}
+
+	t.Run("get", func(t *testing.T) {
+		sub, err := api.SubscriptionByID(s, "a")
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if sub.TriggerOn != TransferStateChangeEvent {
+			t.Errorf("expected trigger %v, but got %v", TransferStateChangeEvent, sub.TriggerOn)
+		}
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		created, deleted, err := api.ReconcileSubscriptions(s, desired)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(created) != 1 || created[0].TriggerOn != BalanceCreditEvent {
+			t.Errorf("unexpected created subscriptions: %#v", created)
+		}
+
+		if len(deleted) != 1 || deleted[0].ID != "b" {
+			t.Errorf("unexpected deleted subscriptions: %#v", deleted)
+		}
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		created, deleted, err := api.ReconcileSubscriptions(s, desired)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(created) != 0 || len(deleted) != 0 {
+			t.Errorf("expected no changes, but created %d and deleted %d", len(created), len(deleted))
+		}
+	})
+
+	t.Run("applicationScope", func(t *testing.T) {
+		if p := ApplicationScope("key").path; p != "v3/applications/key/subscriptions" {
+			t.Errorf("unexpected path %v", p)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package transferwise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	urlpkg "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TransferStatus string
+
+var (
+	IncomingPaymentWaiting         TransferStatus = "incoming_payment_waiting"
+	WaitingRecipientInputToProceed TransferStatus = "waiting_recipient_input_to_proceed"
+	Processing                     TransferStatus = "processing"
+	FundsConverted                 TransferStatus = "funds_converted"
+	OutgoingPaymentSent            TransferStatus = "outgoing_payment_sent"
+	Cancelled                      TransferStatus = "cancelled"
+	FundsRefunded                  TransferStatus = "funds_refunded"
+	BouncedBack                    TransferStatus = "bounced_back"
+	ChargedBack                    TransferStatus = "charged_back"
+)
+
+type TransferDetails struct {
+	Reference                         string `json:"reference,omitempty"`
+	TransferPurpose                   string `json:"transferPurpose,omitempty"`
+	TransferPurposeSubTransferPurpose string `json:"transferPurposeSubTransferPurpose,omitempty"`
+	SourceOfFunds                     string `json:"sourceOfFunds,omitempty"`
+}
+
+type transferRequest struct {
+	TargetAccount         int             `json:"targetAccount"`
+	Quote                 int             `json:"quote,omitempty"`
+	QuoteUUID             string          `json:"quoteUuid,omitempty"`
+	CustomerTransactionID string          `json:"customerTransactionId"`
+	Details               TransferDetails `json:"details"`
+}
+
+type Transfer struct {
+	ID                    int             `json:"id"`
+	User                  int             `json:"user"`
+	TargetAccount         int             `json:"targetAccount"`
+	SourceAccount         int             `json:"sourceAccount"`
+	Quote                 int             `json:"quote"`
+	QuoteUUID             string          `json:"quoteUuid"`
+	Status                TransferStatus  `json:"status"`
+	Reference             string          `json:"reference"`
+	Rate                  Decimal         `json:"rate"`
+	Created               TwTime          `json:"created"`
+	Business              int             `json:"business"`
+	TransferRequest       int             `json:"transferRequest"`
+	Details               TransferDetails `json:"details"`
+	HasActiveIssues       bool            `json:"hasActiveIssues"`
+	SourceCurrency        string          `json:"sourceCurrency"`
+	SourceValue           Decimal         `json:"sourceValue"`
+	TargetCurrency        string          `json:"targetCurrency"`
+	TargetValue           Decimal         `json:"targetValue"`
+	CustomerTransactionID string          `json:"customerTransactionId"`
+}
+
+func (t Transfer) SourceAmount() Amount {
+	return NewAmount(t.SourceValue, t.SourceCurrency)
+}
+
+func (t Transfer) TargetAmount() Amount {
+	return NewAmount(t.TargetValue, t.TargetCurrency)
+}
+
+func newTransferRequest(targetAccount int, customerTransactionID string, details TransferDetails) (transferRequest, error) {
+	if targetAccount == 0 {
+		return transferRequest{}, fmt.Errorf("target account is required")
+	}
+
+	if customerTransactionID == "" {
+		id, err := newUUID()
+		if err != nil {
+			return transferRequest{}, fmt.Errorf("error generating customer transaction ID: %v", err)
+		}
+		customerTransactionID = id
+	}
+
+	r := transferRequest{
+		TargetAccount:         targetAccount,
+		CustomerTransactionID: customerTransactionID,
+		Details:               details,
+	}
+
+	return r, nil
+}
+
+func (q QuoteResponse) TransferRequest(targetAccount int, customerTransactionID string, details TransferDetails) (transferRequest, error) {
+	r, err := newTransferRequest(targetAccount, customerTransactionID, details)
+	if err != nil {
+		return transferRequest{}, err
+	}
+
+	r.Quote = q.ID
+	return r, nil
+}
+
+func (q QuoteV3) TransferRequest(targetAccount int, customerTransactionID string, details TransferDetails) (transferRequest, error) {
+	r, err := newTransferRequest(targetAccount, customerTransactionID, details)
+	if err != nil {
+		return transferRequest{}, err
+	}
+
+	r.QuoteUUID = q.ID
+	return r, nil
+}
+
+func (a *API) CreateTransfer(r transferRequest, options ...ReqOption) (*Transfer, error) {
+	return a.CreateTransferContext(context.Background(), r, options...)
+}
+
+func (a *API) CreateTransferContext(ctx context.Context, r transferRequest, options ...ReqOption) (*Transfer, error) {
+	if r.CustomerTransactionID == "" {
+		id, err := newUUID()
+		if err != nil {
+			return nil, fmt.Errorf("error generating customer transaction ID: %v", err)
+		}
+		r.CustomerTransactionID = id
+	}
+
+	d := Transfer{}
+	if err := a.do(ctx, "v1/transfers", http.MethodPost, r, &d, append([]ReqOption{WithIdempotencyKey(r.CustomerTransactionID)}, options...)...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (a *API) TransferByID(id int) (*Transfer, error) {
+	return a.TransferByIDContext(context.Background(), id)
+}
+
+func (a *API) TransferByIDContext(ctx context.Context, id int) (*Transfer, error) {
+	d := Transfer{}
+	url := fmt.Sprintf("v1/transfers/%d", id)
+	if err := a.do(ctx, url, http.MethodGet, nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+type TransferFilter struct {
+	Status           []TransferStatus
+	CreatedDateStart time.Time
+	CreatedDateEnd   time.Time
+	Limit            int
+	Offset           int
+}
+
+func (f TransferFilter) values(profileID int) urlpkg.Values {
+	q := urlpkg.Values{}
+	q.Set("profile", strconv.Itoa(profileID))
+
+	if len(f.Status) > 0 {
+		s := make([]string, len(f.Status))
+		for i, status := range f.Status {
+			s[i] = string(status)
+		}
+		q.Set("status", strings.Join(s, ","))
+	}
+
+	if !f.CreatedDateStart.IsZero() {
+		q.Set("createdDateStart", f.CreatedDateStart.Format("2006-01-02"))
+	}
+
+	if !f.CreatedDateEnd.IsZero() {
+		q.Set("createdDateEnd", f.CreatedDateEnd.Format("2006-01-02"))
+	}
+
+	if f.Limit > 0 {
+		q.Set("limit", strconv.Itoa(f.Limit))
+	}
+
+	if f.Offset > 0 {
+		q.Set("offset", strconv.Itoa(f.Offset))
+	}
+
+	return q
+}
+
+func (a *API) Transfers(profileID int, f TransferFilter) ([]Transfer, error) {
+	return a.TransfersContext(context.Background(), profileID, f)
+}
+
+func (a *API) TransfersContext(ctx context.Context, profileID int, f TransferFilter) ([]Transfer, error) {
+	res := []Transfer{}
+	if err := a.do(ctx, "v1/transfers?"+f.values(profileID).Encode(), http.MethodGet, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *API) CancelTransfer(id int, options ...ReqOption) (*Transfer, error) {
+	return a.CancelTransferContext(context.Background(), id, options...)
+}
+
+func (a *API) CancelTransferContext(ctx context.Context, id int, options ...ReqOption) (*Transfer, error) {
+	d := Transfer{}
+	url := fmt.Sprintf("v1/transfers/%d/cancel", id)
+	if err := a.do(ctx, url, http.MethodPut, nil, &d, options...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+type FundingType string
+
+var (
+	BalanceFunding FundingType = "BALANCE"
+)
+
+type fundingRequest struct {
+	Type FundingType `json:"type"`
+}
+
+type FundingResult struct {
+	Type                 FundingType `json:"type"`
+	Status               string      `json:"status"`
+	ErrorCode            string      `json:"errorCode"`
+	BalanceTransactionID int         `json:"balanceTransactionId"`
+}
+
+func (a *API) FundTransfer(profileID, transferID int, options ...ReqOption) (*FundingResult, error) {
+	return a.FundTransferContext(context.Background(), profileID, transferID, options...)
+}
+
+func (a *API) FundTransferContext(ctx context.Context, profileID, transferID int, options ...ReqOption) (*FundingResult, error) {
+	d := FundingResult{}
+	url := fmt.Sprintf("v3/profiles/%d/transfers/%d/payments", profileID, transferID)
+	key := WithIdempotencyKey(derivedKey("funding", strconv.Itoa(profileID), strconv.Itoa(transferID)))
+	if err := a.do(ctx, url, http.MethodPost, fundingRequest{Type: BalanceFunding}, &d, append([]ReqOption{key}, options...)...); err != nil {
+		return nil, err
+	}
+
+	if d.Status == "REJECTED" {
+		return &d, fmt.Errorf("funding rejected: %s", d.ErrorCode)
+	}
+
+	return &d, nil
+}
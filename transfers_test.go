@@ -0,0 +1,113 @@
+package transferwise
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTransfers(t *testing.T) {
+	const transfer = `{"id":16521632,"user":4342275,"targetAccount":13,"quote":42,"status":"incoming_payment_waiting","created":"2018-12-20 09:22:27","sourceCurrency":"EUR","sourceValue":100.5,"targetCurrency":"GBP","customerTransactionId":"bd244a95-dcf8-4c31-aac8-bf5e2f3e54c0","details":{"reference":"invoice 1"}}`
+
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/transfers":
+			req := transferRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("expected to pass, but got %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if req.Quote != 42 || req.TargetAccount != 13 || req.CustomerTransactionID == "" {
+				t.Errorf("unexpected request: %#v", req)
+			}
+
+			if r.Header.Get(idempotenceHeader) != req.CustomerTransactionID {
+				t.Errorf("expected the customer transaction ID as idempotency key, but got %v", r.Header.Get(idempotenceHeader))
+			}
+
+			w.Write([]byte(transfer))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/transfers/16521632":
+			w.Write([]byte(transfer))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/transfers":
+			q := r.URL.Query()
+			if q.Get("profile") != "1" || q.Get("status") != "processing,cancelled" || q.Get("createdDateStart") != "2018-12-01" || q.Get("limit") != "10" {
+				t.Errorf("unexpected query: %v", r.URL.RawQuery)
+			}
+
+			w.Write([]byte("[" + transfer + "]"))
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/transfers/16521632/cancel":
+			w.Write([]byte(`{"id":16521632,"status":"cancelled"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	q := QuoteResponse{ID: 42}
+
+	t.Run("create", func(t *testing.T) {
+		if r, err := q.TransferRequest(13, "", TransferDetails{}); err != nil || r.CustomerTransactionID == "" {
+			t.Errorf("expected a generated customer transaction ID, but got %q (%v)", r.CustomerTransactionID, err)
+		}
+
+		req, err := q.TransferRequest(13, "bd244a95-dcf8-4c31-aac8-bf5e2f3e54c0", TransferDetails{Reference: "invoice 1"})
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		tr, err := api.CreateTransfer(req)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if tr.ID != 16521632 || tr.Status != IncomingPaymentWaiting {
+			t.Errorf("unexpected transfer: %#v", tr)
+		}
+
+		if tr.Created.Format("2006-01-02 15:04:05") != "2018-12-20 09:22:27" {
+			t.Errorf("unexpected created time: %v", tr.Created)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		tr, err := api.TransferByID(16521632)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if tr.Details.Reference != "invoice 1" {
+			t.Errorf("expected reference invoice 1, but got %v", tr.Details.Reference)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		f := TransferFilter{
+			Status:           []TransferStatus{Processing, Cancelled},
+			CreatedDateStart: time.Date(2018, 12, 1, 0, 0, 0, 0, time.UTC),
+			Limit:            10,
+		}
+
+		tr, err := api.Transfers(1, f)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if len(tr) != 1 {
+			t.Errorf("expected 1 transfer, but got %d", len(tr))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		tr, err := api.CancelTransfer(16521632)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+
+		if tr.Status != Cancelled {
+			t.Errorf("expected status %v, but got %v", Cancelled, tr.Status)
+		}
+	})
+}
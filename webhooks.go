@@ -0,0 +1,324 @@
+package transferwise
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	webhookSignatureHeader = "X-Signature-SHA256"
+	webhookDeliveryHeader  = "X-Delivery-Id"
+
+	// maxWebhookBody bounds the body read before the signature is verified.
+	maxWebhookBody = 64 << 10
+)
+
+type EventType string
+
+var (
+	TransferStateChangeEvent       EventType = "transfers#state-change"
+	TransferActiveCasesEvent       EventType = "transfers#active-cases"
+	BalanceCreditEvent             EventType = "balances#credit"
+	ProfileVerificationChangeEvent EventType = "profiles#verification-state-change"
+)
+
+type Event struct {
+	DeliveryID     string          `json:"-"`
+	Data           json.RawMessage `json:"data"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventType      EventType       `json:"event_type"`
+	SchemaVersion  string          `json:"schema_version"`
+	SentAt         time.Time       `json:"sent_at"`
+}
+
+type EventResource struct {
+	Type      string `json:"type"`
+	ID        int    `json:"id"`
+	ProfileID int    `json:"profile_id"`
+	AccountID int    `json:"account_id"`
+}
+
+type TransferStateChange struct {
+	Resource      EventResource  `json:"resource"`
+	CurrentState  TransferStatus `json:"current_state"`
+	PreviousState TransferStatus `json:"previous_state"`
+	OccurredAt    time.Time      `json:"occurred_at"`
+}
+
+type TransferActiveCases struct {
+	Resource    EventResource `json:"resource"`
+	ActiveCases []string      `json:"active_cases"`
+}
+
+type BalanceCredit struct {
+	Resource                     EventResource `json:"resource"`
+	TransactionType              string        `json:"transaction_type"`
+	Amount                       Decimal       `json:"amount"`
+	Currency                     string        `json:"currency"`
+	PostTransactionBalanceAmount Decimal       `json:"post_transaction_balance_amount"`
+	OccurredAt                   time.Time     `json:"occurred_at"`
+}
+
+type ProfileVerificationChange struct {
+	Resource     EventResource `json:"resource"`
+	CurrentState string        `json:"current_state"`
+	OccurredAt   time.Time     `json:"occurred_at"`
+}
+
+type WebhookHandler struct {
+	key *rsa.PublicKey
+	ttl time.Duration
+
+	mu       sync.Mutex
+	handlers map[EventType]func(*Event) error
+	inFlight map[string]bool
+	handled  map[string]time.Time
+}
+
+type WebhookOption func(*WebhookHandler) error
+
+func WithDeliveryTTL(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) error {
+		if d <= 0 {
+			return fmt.Errorf("delivery ttl should be positive")
+		}
+
+		h.ttl = d
+		return nil
+	}
+}
+
+func parsePublicKey(b []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if k, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return k, nil
+	}
+
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	rk, ok := k.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA public key, but got %T", k)
+	}
+
+	return rk, nil
+}
+
+func NewWebhookHandler(publicKey []byte, options ...WebhookOption) (*WebhookHandler, error) {
+	k, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	h := WebhookHandler{
+		key:      k,
+		handlers: map[EventType]func(*Event) error{},
+		ttl:      24 * time.Hour,
+		inFlight: map[string]bool{},
+		handled:  map[string]time.Time{},
+	}
+
+	for _, opt := range options {
+		if err := opt(&h); err != nil {
+			return nil, fmt.Errorf("option error: %v", err)
+		}
+	}
+
+	return &h, nil
+}
+
+// Handle registers f for events of type t. It's safe to call while the
+// handler is serving requests.
+func (h *WebhookHandler) Handle(t EventType, f func(*Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[t] = f
+}
+
+func (h *WebhookHandler) handler(t EventType) (func(*Event) error, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, ok := h.handlers[t]
+	return f, ok
+}
+
+func (h *WebhookHandler) OnTransferStateChange(f func(*Event, *TransferStateChange) error) {
+	h.Handle(TransferStateChangeEvent, func(e *Event) error {
+		d := TransferStateChange{}
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return err
+		}
+		return f(e, &d)
+	})
+}
+
+func (h *WebhookHandler) OnTransferActiveCases(f func(*Event, *TransferActiveCases) error) {
+	h.Handle(TransferActiveCasesEvent, func(e *Event) error {
+		d := TransferActiveCases{}
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return err
+		}
+		return f(e, &d)
+	})
+}
+
+func (h *WebhookHandler) OnBalanceCredit(f func(*Event, *BalanceCredit) error) {
+	h.Handle(BalanceCreditEvent, func(e *Event) error {
+		d := BalanceCredit{}
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return err
+		}
+		return f(e, &d)
+	})
+}
+
+func (h *WebhookHandler) OnProfileVerificationChange(f func(*Event, *ProfileVerificationChange) error) {
+	h.Handle(ProfileVerificationChangeEvent, func(e *Event) error {
+		d := ProfileVerificationChange{}
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return err
+		}
+		return f(e, &d)
+	})
+}
+
+func (h *WebhookHandler) verify(body []byte, signature string) error {
+	s, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %v", err)
+	}
+
+	hash := sha256.Sum256(body)
+	return rsa.VerifyPKCS1v15(h.key, crypto.SHA256, hash[:], s)
+}
+
+type deliveryState int
+
+const (
+	newDelivery deliveryState = iota
+	inFlightDelivery
+	handledDelivery
+)
+
+// claim marks a delivery as being processed and returns its previous state.
+// Deliveries are remembered as handled for the ttl once complete succeeds.
+func (h *WebhookHandler) claim(id string) deliveryState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range h.handled {
+		if now.Sub(t) > h.ttl {
+			delete(h.handled, k)
+		}
+	}
+
+	if _, ok := h.handled[id]; ok {
+		return handledDelivery
+	}
+
+	if h.inFlight[id] {
+		return inFlightDelivery
+	}
+
+	h.inFlight[id] = true
+	return newDelivery
+}
+
+func (h *WebhookHandler) complete(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.inFlight, id)
+	h.handled[id] = time.Now()
+}
+
+func (h *WebhookHandler) release(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.inFlight, id)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(body, r.Header.Get(webhookSignatureHeader)); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	e := Event{}
+	if err := json.Unmarshal(body, &e); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	e.DeliveryID = r.Header.Get(webhookDeliveryHeader)
+
+	f, ok := h.handler(e.EventType)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if e.DeliveryID != "" {
+		switch h.claim(e.DeliveryID) {
+		case handledDelivery:
+			w.WriteHeader(http.StatusOK)
+			return
+		case inFlightDelivery:
+			// Wise retries non-2xx responses, so the event isn't lost if the
+			// delivery in flight fails.
+			http.Error(w, "delivery in progress", http.StatusConflict)
+			return
+		}
+	}
+
+	if err := f(&e); err != nil {
+		if e.DeliveryID != "" {
+			h.release(e.DeliveryID)
+		}
+		http.Error(w, "error handling event", http.StatusInternalServerError)
+		return
+	}
+
+	if e.DeliveryID != "" {
+		h.complete(e.DeliveryID)
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,151 @@
+package transferwise
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	k, _ := newTestKey(t)
+	pub, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	h, err := NewWebhookHandler(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	changes := []*TransferStateChange{}
+	fail := false
+	var started, unblock chan struct{}
+	h.OnTransferStateChange(func(e *Event, d *TransferStateChange) error {
+		if unblock != nil {
+			started <- struct{}{}
+			<-unblock
+		}
+
+		if fail {
+			return errors.New("failed")
+		}
+		changes = append(changes, d)
+		return nil
+	})
+
+	credits := 0
+	h.OnBalanceCredit(func(e *Event, d *BalanceCredit) error {
+		if d.Currency != "EUR" || !d.Amount.Equal(MustParseDecimal("1.23")) {
+			t.Errorf("unexpected credit: %#v", d)
+		}
+		credits++
+		return nil
+	})
+
+	send := func(body, delivery string, sign bool) int {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("X-Delivery-Id", delivery)
+		if sign {
+			hash := sha256.Sum256([]byte(body))
+			s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hash[:])
+			if err != nil {
+				t.Fatalf("expected to pass, but got %v", err)
+			}
+			req.Header.Set("X-Signature-SHA256", base64.StdEncoding.EncodeToString(s))
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	const stateChange = `{"data":{"resource":{"type":"transfer","id":111,"profile_id":222,"account_id":333},"current_state":"outgoing_payment_sent","previous_state":"processing","occurred_at":"2020-01-01T12:34:56Z"},"subscription_id":"01234567-89ab-cdef-0123-456789abcdef","event_type":"transfers#state-change","schema_version":"2.0.0","sent_at":"2020-01-01T12:34:56Z"}`
+	const credit = `{"data":{"resource":{"type":"balance-account","id":2,"profile_id":2},"transaction_type":"credit","amount":1.23,"currency":"EUR","post_transaction_balance_amount":2.34,"occurred_at":"2020-01-01T12:34:56Z"},"event_type":"balances#credit","schema_version":"2.0.0"}`
+
+	t.Run("invalidSignature", func(t *testing.T) {
+		if c := send(stateChange, "d0", false); c != http.StatusUnauthorized {
+			t.Errorf("expected status %d, but got %d", http.StatusUnauthorized, c)
+		}
+
+		if len(changes) != 0 {
+			t.Errorf("expected no dispatched events")
+		}
+	})
+
+	t.Run("tooLarge", func(t *testing.T) {
+		if c := send(strings.Repeat(" ", maxWebhookBody+1), "d0", false); c != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status %d, but got %d", http.StatusRequestEntityTooLarge, c)
+		}
+	})
+
+	t.Run("dispatch", func(t *testing.T) {
+		if c := send(stateChange, "d1", true); c != http.StatusOK {
+			t.Fatalf("expected status %d, but got %d", http.StatusOK, c)
+		}
+
+		if len(changes) != 1 || changes[0].CurrentState != OutgoingPaymentSent || changes[0].Resource.ID != 111 {
+			t.Errorf("unexpected changes: %#v", changes)
+		}
+
+		if c := send(credit, "d2", true); c != http.StatusOK || credits != 1 {
+			t.Errorf("expected credit to be dispatched, got status %d and %d credits", c, credits)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		if c := send(stateChange, "d1", true); c != http.StatusOK {
+			t.Fatalf("expected status %d, but got %d", http.StatusOK, c)
+		}
+
+		if len(changes) != 1 {
+			t.Errorf("expected duplicate delivery to be ignored, but got %d changes", len(changes))
+		}
+	})
+
+	t.Run("callbackError", func(t *testing.T) {
+		fail = true
+		if c := send(stateChange, "d3", true); c != http.StatusInternalServerError {
+			t.Errorf("expected status %d, but got %d", http.StatusInternalServerError, c)
+		}
+
+		fail = false
+		if c := send(stateChange, "d3", true); c != http.StatusOK || len(changes) != 2 {
+			t.Errorf("expected failed delivery to be retried, got status %d and %d changes", c, len(changes))
+		}
+	})
+
+	t.Run("inFlight", func(t *testing.T) {
+		started, unblock = make(chan struct{}), make(chan struct{})
+		first := make(chan int)
+		go func() {
+			first <- send(stateChange, "d4", true)
+		}()
+		<-started
+
+		if c := send(stateChange, "d4", true); c != http.StatusConflict {
+			t.Errorf("expected status %d for a delivery in flight, but got %d", http.StatusConflict, c)
+		}
+
+		fail = true
+		close(unblock)
+		if c := <-first; c != http.StatusInternalServerError {
+			t.Errorf("expected status %d, but got %d", http.StatusInternalServerError, c)
+		}
+
+		fail, unblock = false, nil
+		if c := send(stateChange, "d4", true); c != http.StatusOK || len(changes) != 3 {
+			t.Errorf("expected the retried delivery to be handled, got status %d and %d changes", c, len(changes))
+		}
+	})
+}